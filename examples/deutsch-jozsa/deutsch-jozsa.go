@@ -25,16 +25,11 @@ import (
 func main() {
 	qreg := quantum.NewQReg(3, 1)
 	quantum.HadamardReg(qreg)
-	quantum.NewRealArrayGate([]float64{
-		0, 1, 0, 0, 0, 0, 0, 0,
-		1, 0, 0, 0, 0, 0, 0, 0,
-		0, 0, 0, 1, 0, 0, 0, 0,
-		0, 0, 1, 0, 0, 0, 0, 0,
-		0, 0, 0, 0, 1, 0, 0, 0,
-		0, 0, 0, 0, 0, 1, 0, 0,
-		0, 0, 0, 0, 0, 0, 1, 0,
-		0, 0, 0, 0, 0, 0, 0, 1,
-	}).ApplyReg(qreg)
+	// f is balanced: it is 1 exactly when the high input bit is 0.  The
+	// input is held by qubits 1 and 2 and the output by qubit 0.
+	quantum.NewBitOracle(func(x int) bool {
+		return x < 2
+	}, 2).Apply(qreg, []int{1, 2, 0})
 	quantum.HadamardRange(qreg, 1, 3)
 	if qreg.Measure()>>1 == 0 {
 		fmt.Println("constant")
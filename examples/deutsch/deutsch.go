@@ -25,12 +25,10 @@ import (
 func main() {
 	qreg := quantum.NewQReg(2, 1)
 	quantum.HadamardReg(qreg)
-	quantum.NewRealArrayGate([]float64{
-		0, 1, 0, 0,
-		1, 0, 0, 0,
-		0, 0, 1, 0,
-		0, 0, 0, 1,
-	}).ApplyReg(qreg)
+	// f(x) = not x, with the input on qubit 1 and the output on qubit 0
+	quantum.NewBitOracle(func(x int) bool {
+		return x == 0
+	}, 1).Apply(qreg, []int{1, 0})
 	quantum.Hadamard(qreg, 1)
 	if qreg.BMeasure(1) == 0 {
 		fmt.Println("constant")
@@ -18,32 +18,20 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"quantum"
 )
 
 func main() {
 	n := 3
-	qreg := quantum.NewQReg(n+1, 0)
-	quantum.HadamardRange(qreg, 1, n+1)
-	h := quantum.NewHadamardGate(1)
-	u_f := quantum.NewClassicalGate(func(x int) int {
-		if x>>1 == 5 {
-			return x ^ 1
-		}
-		return x
-	},
-		n+1)
-	states := 1 << uint(n)
-	d := quantum.NewDiffusionGate(n)
-	iterations := int((math.Pi * math.Sqrt(float64(states))) / 4.0)
-	for i := 0; i < iterations; i++ {
-		qreg.BSet(0, 1)
-		h.ApplyRange(qreg, 0)
-		u_f.ApplyReg(qreg)
-		d.ApplyRange(qreg, 1)
-	}
-	fmt.Printf("Found %d\n", qreg.Measure()>>1)
+	// The oracle flips the ancilla, qubit n, when the input is 5.
+	u_f := quantum.NewBitOracle(func(x int) bool {
+		return x == 5
+	}, n)
+	iterations := quantum.OptimalIterations(
+		quantum.InitialSuccessProb(u_f, quantum.BitFlipOracle, nil))
+	qreg := quantum.AmplitudeAmplification(u_f, quantum.BitFlipOracle, nil,
+		iterations)
+	fmt.Printf("Found %d\n", qreg.Sub("input").Measure())
 	os.Exit(0)
 }
@@ -18,94 +18,104 @@ package main
 
 import (
 	"fmt"
-	/*"math"*/
-	"os"
-	/*"quantum"*/
 	"math/rand"
+	"os"
+	"quantum"
+	"strconv"
+	"time"
 )
 
-func gcd(a int, b int) int {
-	for a != 0 {
-		a, b = b%a, a
+// Get the smallest prime factor p of n if n is a power of p, or 0 otherwise
+func primePowerFactor(n int) int {
+	p := 2
+	for n%p != 0 {
+		p++
 	}
-	return b
-}
-
-/*func isPrime(n int) bool {*/
-/*if n % 2 == 0 {*/
-/*return false*/
-/*}*/
-/*for r := 3; r < n; r++ {*/
-/*if gcd(n, r) != 1 {*/
-/*return false*/
-/*}*/
-/*if isPrime(r) {*/
-/*let q be the largest factor of r-1*/
-/*if (q > 4sqrt(r)log n) and (n(r-1)/q is not 1 (mod r)) then break*/
-/*}*/
-/*r := r+1*/
-/*}*/
-
-/*for a = 1 to 2sqrt(r)log n {*/
-/*if ( (x-a)n is not (xn-a) (mod xr-1,n) ) then output COMPOSITE*/
-/*}*/
-
-/*output PRIME;*/
-/*}*/
-
-// TODO: Replace with a polynomial time algorithm
-func isPowerOfPrime(n int) bool {
-	for i := 2; i < n; i++ {
-		if n%i == 0 {
-			return true
-		}
+	for n%p == 0 {
+		n /= p
+	}
+	if n == 1 {
+		return p
 	}
-	return false
+	return 0
 }
 
-func newa(bign int) int {
-	return rand.Intn(bign-1) + 1
+func newa(rng *rand.Rand, bign int) int {
+	return rng.Intn(bign-2) + 2
 }
 
-// TODO: Make a real period function
-func period(bign int, a int) int {
-	return 0
+// Get the denominators of the convergents of the continued fraction of
+// num / den, in increasing order
+func convergents(num int, den int) []int {
+	denominators := []int{}
+	q0, q1 := 1, 0 // The denominators of the last two convergents
+	for den != 0 {
+		a := num / den
+		num, den = den, num-a*den
+		q0, q1 = q1, a*q1+q0
+		denominators = append(denominators, q1)
+	}
+	return denominators
 }
 
-func powermod(x int, y int, n int) int {
-	ret := 1
-	for i := 0; i < y; i++ {
-		ret *= x
-		ret %= n
+// Find the order r of a modulo bign, the smallest r > 0 with a**r = 1, by
+// phase estimation on modular exponentiation
+func period(bign int, a int) int {
+	n := 1
+	for 1<<uint(n) < bign {
+		n++
+	}
+	t := 2 * n // Enough counting qubits to resolve every fraction s / r
+	u_f := quantum.NewModExpGate(a, bign, t, n)
+	for {
+		// The counting qubits are 0..t-1 and the output starts as |1>
+		qreg := quantum.NewQReg(t+n, 1<<uint(t))
+		counting := qreg.DefineSubRange("counting", 0, t)
+		quantum.HadamardRange(qreg, 0, t)
+		u_f.ApplyReg(qreg)
+		quantum.InverseQFTRange(qreg, 0, t)
+		// The inverse QFT of e**(2 pi i x s / r) peaks at y = 2**t s / r
+		y := counting.Measure()
+		for _, r := range convergents(y, 1<<uint(t)) {
+			if r > 0 && r < bign && quantum.PowMod(a, r, bign) == 1 {
+				return r
+			}
+		}
 	}
-	return ret
 }
 
 func main() {
-	/*bign := 6 // 2 * 3*/
-	/*n := int(math.Ceil(math.Log2(float64(bign))))*/
-	/*isPowerOfPrime(n)*/
-	/*qreg := quantum.NewQReg(3*n, 0)*/
-	/*quantum.HadamardRange(qreg, n, qreg.Size())*/
-	/*qreg.Print()*/
-	/*fmt.Println()*/
-
-	/*var factor int*/
-	/*for a := newa(bign); ; a = newa(bign) {*/
-		/*factor = gcd(a, N)*/
-		/*if factor != 1 {*/
-			/*break*/
-		/*}*/
-
-		/*r := period(bign, a)*/
-		/*if r%2 == 0 && powermod(a, r, bign) != bign-1 {*/
-			/*pow := math.Pow(a, r/2)*/
-			/*factor = gcd(pow+1, bign)*/
-			/*break*/
-		/*}*/
-	/*}*/
-
-	/*fmt.Printf("%d %d\n", common, N/common)*/
-	fmt.Println("This doesn't work yet.  Be patient.")
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	bign := 15 // 3 * 5
+	if len(os.Args) > 1 {
+		n, err := strconv.Atoi(os.Args[1])
+		if err != nil || n < 4 {
+			fmt.Println("N must be a composite integer of at least 4")
+			os.Exit(1)
+		}
+		bign = n
+	}
+	// Period finding cannot split even numbers or prime powers, but
+	// they are easy to factor classically
+	factor := primePowerFactor(bign)
+	if factor == bign {
+		fmt.Printf("%d is prime\n", bign)
+		os.Exit(1)
+	}
+	if bign%2 == 0 {
+		factor = 2
+	}
+	for factor == 0 {
+		a := newa(rng, bign)
+		if factor = quantum.GCD(a, bign); factor != 1 {
+			break
+		}
+		factor = 0
+		r := period(bign, a)
+		if r%2 == 0 && quantum.PowMod(a, r/2, bign) != bign-1 {
+			factor = quantum.GCD(quantum.PowMod(a, r/2, bign)+1, bign)
+		}
+	}
+	fmt.Printf("%d = %d * %d\n", bign, factor, bign/factor)
 	os.Exit(0)
 }
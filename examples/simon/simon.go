@@ -18,88 +18,56 @@ package main
 
 import (
 	"fmt"
-	"math"
+	"gf2"
 	"os"
 	"quantum"
+	"strconv"
 )
 
-func getParity(x int) int {
-	p := 0
-	for i := x; i != 0; i >>= 1 {
-		p ^= i & 1
-	}
-	return p
-}
-
-func solveLinearSystem(system []int) int {
-	bits := len(system)
-	for pos := 0; pos < bits; pos++ {
-		mask := 1 << uint(pos)
-		// Find the first element with this mask and swap with the
-		// current position
-		for i := pos; i < bits; i++ {
-			if system[i]&mask != 0 {
-				temp := system[i]
-				system[i] = system[pos]
-				system[pos] = temp
-				break
-			}
-		}
-		for i := pos + 1; i < bits; i++ {
-			if system[i]&mask != 0 {
-				system[i] ^= system[pos]
-			}
+func main() {
+	bits := 3
+	secret := 5 // 101
+	if len(os.Args) > 1 {
+		s, err := strconv.Atoi(os.Args[1])
+		if err != nil || s < 0 || s >= 1<<uint(bits) {
+			fmt.Printf("Secret must be an integer from 0 to %d\n",
+				1<<uint(bits)-1)
+			os.Exit(1)
 		}
+		secret = s
 	}
-	c := 0
-	for pos := bits - 1; pos < bits; pos-- {
-		bit := getParity(system[pos]^(1<<uint(pos))) << uint(pos)
-		c |= bit
-		if bit == 0 {
-			for i := 0; i < pos; i++ {
-				system[i] &= math.MaxInt32 ^ (1 << uint(pos))
-			}
+	// f(x) = f(x ^ secret), and f is otherwise one-to-one
+	f := func(x int) int {
+		if x^secret < x {
+			return x ^ secret
 		}
+		return x
 	}
-	return c
-}
-
-func main() {
-	bits := 3
 	h := quantum.NewHadamardGate(bits)
 	u_f := quantum.NewClassicalGate(func(x int) int {
-		secret := 5 // 101
-		value := -1
-		y := x >> 3
-		smaller := y ^ secret
-		if y < smaller {
-			smaller = y
-		}
-		for i := 0; i <= smaller; i++ {
-			if i < i^secret {
-				value++
-			}
-		}
-		return x ^ value
+		return x ^ f(x>>uint(bits))
 	},
 		2*bits) // This function maps {0, 1}^6 -> {0, 1}^6
-	system := make([]int, bits) // This holds the values in our linear
-	// system
-	system_map := make(map[int]bool)
-	// Fill our linear system
-	for len(system_map) < bits {
+	// Every measurement y satisfies y . secret = 0, so collect equations
+	// until they leave a single nonzero candidate
+	system := gf2.NewBasis(bits)
+	for system.Rank() < bits-1 {
 		qreg := quantum.NewQReg(2*bits, 0)
-		h.ApplyRange(qreg, bits)
+		input := qreg.DefineSubRange("input", bits, 2*bits)
+		h.ApplySub(input)
 		u_f.ApplyReg(qreg)
-		h.ApplyRange(qreg, bits)
-		value := qreg.Measure() >> uint(bits)
-		if value != 0 && system_map[value] == false {
-			system[len(system_map)] = value
-			system_map[value] = true
-		}
+		h.ApplySub(input)
+		system.Add(gf2.Vector(input.Measure()))
+	}
+	// Solve the linear system.  If the secret is zero, the equations are
+	// random and the candidate must be ruled out classically.
+	candidate := int(system.Matrix().Nullspace()[0])
+	if f(0) != f(candidate) {
+		candidate = 0
+	}
+	fmt.Printf("Secret is %d\n", candidate)
+	if candidate != secret {
+		os.Exit(1)
 	}
-	// Solve the linear system
-	secret := solveLinearSystem(system)
-	fmt.Printf("Secret is %d\n", secret)
 	os.Exit(0)
 }
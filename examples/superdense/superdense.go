@@ -0,0 +1,51 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"quantum"
+)
+
+func main() {
+	for message := 0; message < 4; message++ {
+		// Qubit 0 is Alice's half of a Bell pair and qubit 1 is Bob's.
+		c := quantum.NewCircuit(2, "b0", "b1")
+		c.Apply(quantum.NewHadamardGate(1), 0)
+		c.Apply(quantum.NewCNOTGate(), 0, 1)
+		// Alice encodes two bits by acting on her qubit alone...
+		if message&1 == 1 {
+			c.Apply(quantum.NewPauliXGate(), 0)
+		}
+		if message&2 == 2 {
+			c.Apply(quantum.NewPauliZGate(), 0)
+		}
+		// ...and Bob decodes them with a Bell measurement.
+		c.Apply(quantum.NewCNOTGate(), 0, 1)
+		c.Apply(quantum.NewHadamardGate(1), 0)
+		c.Measure(1, "b0")
+		c.Measure(0, "b1")
+
+		received := c.Run(quantum.NewQReg(2, 0)).Value("b0", "b1")
+		fmt.Printf("Sent %02b, received %02b\n", message, received)
+		if received != message {
+			os.Exit(1)
+		}
+	}
+	os.Exit(0)
+}
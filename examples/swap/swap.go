@@ -0,0 +1,59 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"quantum"
+)
+
+func main() {
+	// Qubits 0 and 1 share a Bell pair, as do qubits 2 and 3.
+	c := quantum.NewCircuit(4, "m1", "m2")
+	for _, pair := range [][]int{{0, 1}, {2, 3}} {
+		c.Apply(quantum.NewHadamardGate(1), pair[0])
+		c.Apply(quantum.NewCNOTGate(), pair[0], pair[1])
+	}
+	// A Bell measurement of qubits 1 and 2, followed by corrections on
+	// qubit 3, leaves qubits 0 and 3 sharing a Bell pair although they
+	// never interacted.
+	c.Apply(quantum.NewCNOTGate(), 1, 2)
+	c.Apply(quantum.NewHadamardGate(1), 1)
+	c.Measure(1, "m1")
+	c.Measure(2, "m2")
+	c.Apply(quantum.NewPauliXGate(), 3).CIf(1, "m2")
+	c.Apply(quantum.NewPauliZGate(), 3).CIf(1, "m1")
+
+	qreg := quantum.NewQReg(4, 0)
+	creg := c.Run(qreg)
+	fmt.Printf("Bell measurement gave %d%d\n", creg.Get("m2"),
+		creg.Get("m1"))
+
+	bell := quantum.NewQReg(2, 0)
+	quantum.Hadamard(bell, 0)
+	quantum.CNOT(bell, 0, 1)
+	swapped := qreg.PartialTrace([]int{0, 3})
+	fidelity := swapped.Fidelity(quantum.NewDensityMatrix(bell))
+	fmt.Printf("Fidelity with a Bell pair %f\n", fidelity)
+	fmt.Printf("Concurrence of qubits 0 and 3 %f\n", qreg.Concurrence(0, 3))
+	if math.Abs(fidelity-1) > .0000000001 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"quantum"
+)
+
+func main() {
+	// The message is cos(theta/2)|0> + e^(i phi) sin(theta/2)|1>
+	theta, phi := math.Pi/3, math.Pi/5
+	message := quantum.NewQReg(1, 0)
+	quantum.NewArrayGate([]complex128{
+		complex(math.Cos(theta/2), 0), complex(-math.Sin(theta/2), 0),
+		complex(math.Cos(phi)*math.Sin(theta/2), math.Sin(phi)*math.Sin(theta/2)),
+		complex(math.Cos(phi)*math.Cos(theta/2), math.Sin(phi)*math.Cos(theta/2)),
+	}).ApplyReg(message)
+
+	// Qubit 0 holds the message, qubit 1 is Alice's half of a Bell pair
+	// and qubit 2 is Bob's half.
+	c := quantum.NewCircuit(3, "m0", "m1")
+	c.Apply(quantum.NewHadamardGate(1), 1)
+	c.Apply(quantum.NewCNOTGate(), 1, 2)
+	// Alice measures in the Bell basis...
+	c.Apply(quantum.NewCNOTGate(), 0, 1)
+	c.Apply(quantum.NewHadamardGate(1), 0)
+	c.Measure(0, "m0")
+	c.Measure(1, "m1")
+	// ...and Bob corrects his qubit using her two classical bits.
+	c.Apply(quantum.NewPauliXGate(), 2).CIf(1, "m1")
+	c.Apply(quantum.NewPauliZGate(), 2).CIf(1, "m0")
+
+	qreg := message.Tensor(quantum.NewQReg(2, 0))
+	creg := c.Run(qreg)
+	received, ok := qreg.SubsystemState([]int{2})
+	if !ok {
+		fmt.Println("Bob's qubit is still entangled")
+		os.Exit(1)
+	}
+	fmt.Printf("Alice measured %d%d\n", creg.Get("m1"), creg.Get("m0"))
+	fmt.Println("Message:")
+	message.Print()
+	fmt.Println("Received:")
+	received.Print()
+	fidelity := received.Fidelity(message)
+	fmt.Printf("Fidelity %f\n", fidelity)
+	if math.Abs(fidelity-1) > .0000000001 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
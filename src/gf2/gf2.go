@@ -0,0 +1,215 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+// Package gf2 provides linear algebra over GF(2), the field of two elements,
+// on bit vectors of up to 64 entries.
+package gf2
+
+import (
+	"fmt"
+)
+
+// A bit vector with entry i stored as bit i
+type Vector uint64
+
+// Get the dot product of two vectors, which is the parity of their
+// common bits
+func Dot(a Vector, b Vector) int {
+	par := 0
+	for anded := a & b; anded > 0; anded >>= 1 {
+		par ^= int(anded & 1)
+	}
+	return par
+}
+
+// Get entry i of a vector
+func (v Vector) Bit(i int) int {
+	return int(v>>uint(i)) & 1
+}
+
+// Represents a matrix over GF(2) as a list of rows
+type Matrix struct {
+	// The number of columns (at most 64).
+	cols int
+
+	rows []Vector
+}
+
+func checkCols(cols int) {
+	if cols < 0 || cols > 64 {
+		panic(fmt.Sprintf("%d columns is not between 0 and 64", cols))
+	}
+}
+
+// Constructor for a Matrix with the given rows
+func NewMatrix(cols int, rows ...Vector) *Matrix {
+	checkCols(cols)
+	m := &Matrix{cols: cols}
+	for _, row := range rows {
+		m.AddRow(row)
+	}
+	return m
+}
+
+// Accessor for the number of columns of a Matrix
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+// Accessor for the number of rows of a Matrix
+func (m *Matrix) Rows() int {
+	return len(m.rows)
+}
+
+// Accessor for a row of a Matrix
+func (m *Matrix) Row(i int) Vector {
+	return m.rows[i]
+}
+
+// Append a row to the matrix
+func (m *Matrix) AddRow(row Vector) {
+	if m.cols < 64 && row>>uint(m.cols) != 0 {
+		panic(fmt.Sprintf("Row %b is wider than %d columns", row, m.cols))
+	}
+	m.rows = append(m.rows, row)
+}
+
+// Copy a matrix
+func (m *Matrix) Copy() *Matrix {
+	return NewMatrix(m.cols, m.rows...)
+}
+
+// Get the reduced row echelon form of the matrix by Gaussian elimination.
+// Zero rows are dropped, so the result has one row per pivot, and the pivot
+// of each row is its lowest set bit.  The pivot columns are returned in
+// order along with the reduced matrix.
+func (m *Matrix) Reduce() (*Matrix, []int) {
+	rows := make([]Vector, len(m.rows))
+	copy(rows, m.rows)
+	pivots := []int{}
+	rank := 0
+	for col := 0; col < m.cols && rank < len(rows); col++ {
+		mask := Vector(1) << uint(col)
+		// Find a row with this column set and swap it into place
+		found := -1
+		for i := rank; i < len(rows); i++ {
+			if rows[i]&mask != 0 {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			continue
+		}
+		rows[rank], rows[found] = rows[found], rows[rank]
+		// Clear the column from every other row
+		for i := range rows {
+			if i != rank && rows[i]&mask != 0 {
+				rows[i] ^= rows[rank]
+			}
+		}
+		pivots = append(pivots, col)
+		rank++
+	}
+	return NewMatrix(m.cols, rows[:rank]...), pivots
+}
+
+// Get the rank of the matrix
+func (m *Matrix) Rank() int {
+	_, pivots := m.Reduce()
+	return len(pivots)
+}
+
+// Get a basis of the nullspace of the matrix, the vectors x for which every
+// row has a zero dot product with x
+func (m *Matrix) Nullspace() []Vector {
+	reduced, pivots := m.Reduce()
+	is_pivot := make(map[int]bool)
+	for _, pivot := range pivots {
+		is_pivot[pivot] = true
+	}
+	basis := []Vector{}
+	for free := 0; free < m.cols; free++ {
+		if is_pivot[free] {
+			continue
+		}
+		// Set the free variable and solve for the pivot variables
+		x := Vector(1) << uint(free)
+		for i, pivot := range pivots {
+			if reduced.rows[i].Bit(free) == 1 {
+				x |= Vector(1) << uint(pivot)
+			}
+		}
+		basis = append(basis, x)
+	}
+	return basis
+}
+
+// Represents the span of a growing set of vectors, kept in echelon form so
+// that the independence of a new vector can be checked in O(rank) steps
+type Basis struct {
+	cols int
+
+	// Each row has a distinct pivot, its lowest set bit, which no later
+	// row has set.
+	rows []Vector
+}
+
+// Constructor for an empty Basis
+func NewBasis(cols int) *Basis {
+	checkCols(cols)
+	return &Basis{cols: cols}
+}
+
+// Reduce a vector against the basis, leaving zero if it is in the span
+func (b *Basis) reduce(v Vector) Vector {
+	for _, row := range b.rows {
+		pivot := row & -row
+		if v&pivot != 0 {
+			v ^= row
+		}
+	}
+	return v
+}
+
+// This tells us whether a vector is outside the span of the basis
+func (b *Basis) IsIndependent(v Vector) bool {
+	return b.reduce(v) != 0
+}
+
+// Add a vector to the basis if it is independent of it, reporting whether it
+// was added
+func (b *Basis) Add(v Vector) bool {
+	if b.cols < 64 && v>>uint(b.cols) != 0 {
+		panic(fmt.Sprintf("Vector %b is wider than %d columns", v, b.cols))
+	}
+	r := b.reduce(v)
+	if r == 0 {
+		return false
+	}
+	b.rows = append(b.rows, r)
+	return true
+}
+
+// Accessor for the number of vectors in a Basis
+func (b *Basis) Rank() int {
+	return len(b.rows)
+}
+
+// Get the basis vectors as the rows of a matrix
+func (b *Basis) Matrix() *Matrix {
+	return NewMatrix(b.cols, b.rows...)
+}
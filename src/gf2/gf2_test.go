@@ -0,0 +1,100 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package gf2
+
+import (
+	"testing"
+)
+
+func TestDot(t *testing.T) {
+	if d := Dot(6, 3); d != 1 {
+		t.Errorf("Bad Dot(110, 011) = %d, want 1", d)
+	}
+	if d := Dot(7, 5); d != 0 {
+		t.Errorf("Bad Dot(111, 101) = %d, want 0", d)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	m := NewMatrix(4, 3, 6, 5, 8)
+	reduced, pivots := m.Reduce()
+	want := []int{0, 1, 3}
+	if len(pivots) != len(want) {
+		t.Fatalf("Bad pivots %v, want %v", pivots, want)
+	}
+	for i, pivot := range want {
+		if pivots[i] != pivot {
+			t.Errorf("Bad pivot %d = %d, want %d", i, pivots[i], pivot)
+		}
+		// Each pivot column is set in exactly its own row
+		for j := 0; j < reduced.Rows(); j++ {
+			bit := reduced.Row(j).Bit(pivot)
+			if (bit == 1) != (i == j) {
+				t.Errorf("Bad bit %d of row %d = %d", pivot, j, bit)
+			}
+		}
+	}
+	if rank := m.Rank(); rank != 3 {
+		t.Errorf("Bad rank = %d, want 3", rank)
+	}
+}
+
+func TestNullspace(t *testing.T) {
+	m := NewMatrix(5, 3, 6, 24)
+	null := m.Nullspace()
+	if len(null) != 2 {
+		t.Fatalf("Bad nullspace dimension = %d, want 2", len(null))
+	}
+	if NewMatrix(5, null...).Rank() != 2 {
+		t.Error("Nullspace basis is dependent")
+	}
+	for _, x := range null {
+		for i := 0; i < m.Rows(); i++ {
+			if Dot(m.Row(i), x) != 0 {
+				t.Errorf("Row %b is not orthogonal to %b", m.Row(i), x)
+			}
+		}
+	}
+	if null := NewMatrix(3).Nullspace(); len(null) != 3 {
+		t.Errorf("Bad nullspace dimension of empty matrix = %d, want 3",
+			len(null))
+	}
+}
+
+func TestBasis(t *testing.T) {
+	b := NewBasis(3)
+	for _, v := range []Vector{5, 3, 6, 0, 7} {
+		b.Add(v)
+	}
+	if b.Rank() != 3 {
+		t.Errorf("Bad rank = %d, want 3", b.Rank())
+	}
+	b = NewBasis(3)
+	if !b.Add(5) || !b.Add(3) {
+		t.Fatal("Independent vector rejected")
+	}
+	if b.IsIndependent(6) || b.Add(6) {
+		t.Error("Dependent vector 110 accepted")
+	}
+	if !b.IsIndependent(1) {
+		t.Error("Independent vector 001 rejected")
+	}
+	null := b.Matrix().Nullspace()
+	if len(null) != 1 || null[0] != 7 {
+		t.Errorf("Bad nullspace %v, want [111]", null)
+	}
+}
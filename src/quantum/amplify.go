@@ -0,0 +1,239 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+)
+
+// Kinds of oracle that mark the solutions of a search problem.  With n input
+// qubits, a phase oracle acts on qubits 0 to n-1 and a bit-flip oracle also
+// acts on an ancilla, qubit n.
+type OracleKind int
+
+const (
+	// Maps |x> to (-1)**f(x) |x>
+	PhaseOracle OracleKind = iota
+
+	// Maps |x>|y> to |x>|y ^ f(x)>
+	BitFlipOracle
+)
+
+// Get the number of input qubits of an oracle
+func oracleInputBits(oracle *Gate, kind OracleKind) int {
+	if kind == BitFlipOracle {
+		return oracle.bits() - 1
+	}
+	return oracle.bits()
+}
+
+// Make the reflection 2|0><0| - I
+func newZeroReflectionGate(bits int) *Gate {
+	diagonal := make([]complex128, 1<<uint(bits))
+	for i := range diagonal {
+		diagonal[i] = complex(-1, 0)
+	}
+	diagonal[0] = complex(1, 0)
+	return NewDiagonalGate(diagonal).SetName("S0")
+}
+
+// Append the Grover iterate A S_0 A^dagger S_f to a circuit whose qubits are
+// laid out as for AmplitudeAmplification.  A nil prep stands for the
+// Hadamard transform, for which A S_0 A^dagger is the diffusion gate.
+func appendGroverIterate(c *Circuit, oracle *Gate, kind OracleKind, prep *Gate) {
+	n := oracleInputBits(oracle, kind)
+	c.Apply(oracle, qubitRange(0, oracle.bits())...)
+	if prep == nil {
+		c.Apply(NewDiffusionGate(n), qubitRange(0, n)...)
+		return
+	}
+	c.Apply(prep.Adjoint(), qubitRange(0, n)...)
+	c.Apply(newZeroReflectionGate(n), qubitRange(0, n)...)
+	c.Apply(prep, qubitRange(0, n)...)
+}
+
+// Make the Grover iterate A S_0 A^dagger S_f as a single gate acting on the
+// same qubits as the oracle.  A nil prep stands for the Hadamard transform.
+func NewGroverGate(oracle *Gate, kind OracleKind, prep *Gate) *Gate {
+	c := NewCircuit(oracle.bits())
+	appendGroverIterate(c, oracle, kind, prep)
+	return c.ToGate().SetName("G")
+}
+
+// Build the circuit for amplitude amplification.  The input qubits, named
+// "input" on the register it is run on, are prepared by prep (the Hadamard
+// transform if nil) and then amplified by the given number of Grover
+// iterations.  For a bit-flip oracle the ancilla, named "ancilla", is
+// prepared as |-> and returned to |1> at the end.
+func NewAmplificationCircuit(oracle *Gate, kind OracleKind, prep *Gate, iterations int) *Circuit {
+	n := oracleInputBits(oracle, kind)
+	if prep != nil && prep.bits() != n {
+		panic(fmt.Sprintf("%d-bit preparation for %d-bit oracle",
+			prep.bits(), n))
+	}
+	c := NewCircuit(oracle.bits())
+	if kind == BitFlipOracle {
+		c.Apply(NewPauliXGate(), n)
+		c.Apply(NewHadamardGate(1), n)
+	}
+	if prep == nil {
+		c.Apply(NewHadamardGate(n), qubitRange(0, n)...)
+	} else {
+		c.Apply(prep, qubitRange(0, n)...)
+	}
+	for i := 0; i < iterations; i++ {
+		appendGroverIterate(c, oracle, kind, prep)
+	}
+	if kind == BitFlipOracle {
+		c.Apply(NewHadamardGate(1), n)
+	}
+	return c
+}
+
+// Run amplitude amplification with the given number of iterations (see
+// NewAmplificationCircuit) and return the resulting register
+func AmplitudeAmplification(oracle *Gate, kind OracleKind, prep *Gate, iterations int) *QReg {
+	c := NewAmplificationCircuit(oracle, kind, prep, iterations)
+	qreg := NewQReg(c.Width(), 0)
+	n := oracleInputBits(oracle, kind)
+	qreg.DefineSubRange("input", 0, n)
+	if kind == BitFlipOracle {
+		qreg.DefineSubRange("ancilla", n, n+1)
+	}
+	c.Run(qreg)
+	return qreg
+}
+
+// Get the probability that measuring the input qubits after prep (the
+// Hadamard transform if nil) gives a solution marked by the oracle
+func InitialSuccessProb(oracle *Gate, kind OracleKind, prep *Gate) float64 {
+	n := oracleInputBits(oracle, kind)
+	qreg := NewQReg(n, 0)
+	if prep == nil {
+		HadamardReg(qreg)
+	} else {
+		prep.ApplyReg(qreg)
+	}
+	prob := float64(0.0)
+	for x := range qreg.amplitudes {
+		// A phase oracle negates a solution and a bit-flip oracle
+		// flips the ancilla
+		marked := cmplx.Abs(oracle.get(x, x)+1) < .0000000001
+		if kind == BitFlipOracle {
+			marked = cmplx.Abs(oracle.get(x|1<<uint(n), x)-1) <
+				.0000000001
+		}
+		if marked {
+			prob += qreg.StateProb(x)
+		}
+	}
+	return prob
+}
+
+// Get the number of Grover iterations that maximizes the probability of
+// measuring a solution, given the probability of measuring one straight
+// after the preparation (k/N for k solutions among N items when the
+// preparation is uniform; see InitialSuccessProb)
+func OptimalIterations(prob float64) int {
+	if prob <= 0 || prob > 1 {
+		panic(fmt.Sprintf("Cannot search with success probability %f",
+			prob))
+	}
+	// Each iteration rotates the state by 2 theta towards the solutions,
+	// starting theta away from the non-solutions.
+	theta := math.Asin(math.Sqrt(prob))
+	return int(math.Floor(math.Pi / (4 * theta)))
+}
+
+// Estimate the number of inputs that an oracle marks by phase estimation of
+// the Grover iterate, using the given number of counting qubits.  The
+// estimate is returned along with lower and upper bounds that hold with
+// probability at least 8/pi**2.
+func QuantumCounting(oracle *Gate, kind OracleKind, precision int) (float64, float64, float64) {
+	n := oracleInputBits(oracle, kind)
+	items := float64(int(1) << uint(n))
+	// The uniform superposition (with the ancilla in |->) is a combination
+	// of the two eigenvectors of the Grover iterate, whose eigenphases are
+	// +-theta/pi with sin**2 theta = solutions/items.
+	prep := NewCircuit(oracle.bits())
+	prep.Apply(NewHadamardGate(n), qubitRange(0, n)...)
+	if kind == BitFlipOracle {
+		prep.Apply(NewPauliXGate(), n)
+		prep.Apply(NewHadamardGate(1), n)
+	}
+	probs := PhaseEstimation(NewGroverGate(oracle, kind, nil),
+		prep.ToGate(), precision)
+	m := float64(int(1) << uint(precision))
+	return countFromPhase(float64(sampleDistribution(probs))/m, items, m)
+}
+
+// Convert a measured eigenphase of the Grover iterate, estimated with m
+// counting values, into a count of solutions among the items and its bounds
+func countFromPhase(phase float64, items float64, m float64) (float64, float64, float64) {
+	sin := math.Sin(math.Pi * phase)
+	estimate := items * sin * sin
+	// Brassard, Hoyer, Mosca and Tapp bound the error by
+	// 2 pi sqrt(k (N - k)) / M + pi**2 N / M**2.
+	err := 2*math.Pi*math.Sqrt(estimate*(items-estimate))/m +
+		math.Pi*math.Pi*items/(m*m)
+	return estimate, math.Max(0, estimate-err), math.Min(items, estimate+err)
+}
+
+// Sample an index from a probability distribution
+func sampleDistribution(probs []float64) int {
+	r := rand.Float64()
+	sum := float64(0.0)
+	for i, prob := range probs {
+		sum += prob
+		if r < sum {
+			return i
+		}
+	}
+	return len(probs) - 1
+}
+
+// Search for a solution when the number of solutions is unknown, using the
+// randomized schedule of Boyer, Brassard, Hoyer and Tapp.  Candidates from
+// amplitude amplification are checked with isSolution.  The search gives up,
+// returning false, once the total number of iterations makes it unlikely
+// that there are any solutions.
+func BBHTSearch(oracle *Gate, kind OracleKind, prep *Gate, isSolution func(x int) bool) (int, bool) {
+	n := oracleInputBits(oracle, kind)
+	if n == 0 {
+		// The only item is 0, and with one item the schedule below
+		// never runs any iterations
+		return 0, isSolution(0)
+	}
+	sqrt_items := math.Sqrt(float64(int(1) << uint(n)))
+	lambda := 6.0 / 5.0
+	m := 1.0
+	total := 0
+	for float64(total) <= 9*sqrt_items {
+		iterations := rand.Intn(int(math.Ceil(m)))
+		total += iterations
+		x := AmplitudeAmplification(oracle, kind, prep,
+			iterations).Sub("input").Measure()
+		if isSolution(x) {
+			return x, true
+		}
+		m = math.Min(lambda*m, sqrt_items)
+	}
+	return 0, false
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"testing"
+)
+
+// Helper function for testing. Makes a bit-flip oracle on bits input qubits
+// that marks the given values.
+func newTestBitFlipOracle(bits int, marked ...int) *Gate {
+	return NewClassicalGate(func(x int) int {
+		for _, m := range marked {
+			if x&(1<<uint(bits)-1) == m {
+				return x ^ 1<<uint(bits)
+			}
+		}
+		return x
+	}, bits+1)
+}
+
+// Helper function for testing. Makes a phase oracle on bits input qubits that
+// marks the given values.
+func newTestPhaseOracle(bits int, marked ...int) *Gate {
+	return NewFuncGateNoCheck(func(row int, col int) complex128 {
+		if row != col {
+			return 0
+		}
+		for _, m := range marked {
+			if row == m {
+				return -1
+			}
+		}
+		return 1
+	}, bits)
+}
+
+func TestOptimalIterations(t *testing.T) {
+	if i := OptimalIterations(1.0 / 8); i != 2 {
+		t.Errorf("Bad iterations for 1 of 8 = %d, want 2", i)
+	}
+	if i := OptimalIterations(1.0 / 1024); i != 25 {
+		t.Errorf("Bad iterations for 1 of 1024 = %d, want 25", i)
+	}
+	if i := OptimalIterations(1.0 / 4); i != 1 {
+		t.Errorf("Bad iterations for 1 of 4 = %d, want 1", i)
+	}
+}
+
+func TestAmplitudeAmplification_BitFlip(t *testing.T) {
+	oracle := newTestBitFlipOracle(4, 11)
+	qreg := AmplitudeAmplification(oracle, BitFlipOracle, nil,
+		OptimalIterations(1.0/16))
+	if p := qreg.Sub("input").Prob(11); p < .95 {
+		t.Errorf("Bad probability of the solution = %f", p)
+	}
+	if p := qreg.Sub("ancilla").Prob(1); math.Abs(p-1) > 1e-9 {
+		t.Errorf("Ancilla was not returned to |1>")
+	}
+}
+
+func TestAmplitudeAmplification_PhaseMultiple(t *testing.T) {
+	oracle := newTestPhaseOracle(4, 2, 7, 12)
+	qreg := AmplitudeAmplification(oracle, PhaseOracle, nil,
+		OptimalIterations(3.0/16))
+	input := qreg.Sub("input")
+	if p := input.Prob(2) + input.Prob(7) + input.Prob(12); p < .9 {
+		t.Errorf("Bad probability of a solution = %f", p)
+	}
+}
+
+func TestAmplitudeAmplification_Prep(t *testing.T) {
+	// Without iterations, only the preparation is applied
+	prep := NewHadamardGate(1)
+	oracle := newTestPhaseOracle(1, 1)
+	qreg := AmplitudeAmplification(oracle, PhaseOracle, prep, 0)
+	if p := qreg.Sub("input").Prob(1); math.Abs(p-.5) > 1e-9 {
+		t.Errorf("Bad initial probability = %f, want 0.5", p)
+	}
+	// With a biased preparation, sin**2 theta = 1/4 and one iteration
+	// gives sin**2 (3 theta) = 1.
+	prep = NewRealArrayGate([]float64{
+		math.Sqrt(.75), -.5,
+		.5, math.Sqrt(.75),
+	})
+	qreg = AmplitudeAmplification(oracle, PhaseOracle, prep, 1)
+	if p := qreg.Sub("input").Prob(1); math.Abs(p-1) > 1e-9 {
+		t.Errorf("Bad amplified probability = %f, want 1", p)
+	}
+}
+
+func TestOptimalIterations_Prep(t *testing.T) {
+	// The preparation gives the solution, 1, probability 0.01 rather than
+	// the 1/2 of a uniform preparation
+	prep := NewRealArrayGate([]float64{
+		math.Sqrt(.99), -.1,
+		.1, math.Sqrt(.99),
+	})
+	for _, kind := range []OracleKind{PhaseOracle, BitFlipOracle} {
+		oracle := newTestPhaseOracle(1, 1)
+		if kind == BitFlipOracle {
+			oracle = newTestBitFlipOracle(1, 1)
+		}
+		prob := InitialSuccessProb(oracle, kind, prep)
+		if math.Abs(prob-.01) > 1e-9 {
+			t.Errorf("Bad initial success probability %f, want 0.01",
+				prob)
+		}
+		iterations := OptimalIterations(prob)
+		if iterations != 7 {
+			t.Errorf("Bad iterations %d, want 7", iterations)
+		}
+		qreg := AmplitudeAmplification(oracle, kind, prep, iterations)
+		if p := qreg.Sub("input").Prob(1); p < .99 {
+			t.Errorf("Bad amplified probability = %f", p)
+		}
+	}
+	if p := InitialSuccessProb(newTestPhaseOracle(3, 2, 5), PhaseOracle,
+		nil); math.Abs(p-.25) > 1e-9 {
+		t.Errorf("Bad uniform success probability %f, want 0.25", p)
+	}
+}
+
+func TestBBHTSearch(t *testing.T) {
+	marked := map[int]bool{3: true, 9: true}
+	oracle := newTestBitFlipOracle(4, 3, 9)
+	x, ok := BBHTSearch(oracle, BitFlipOracle, nil, func(x int) bool {
+		return marked[x]
+	})
+	if !ok || !marked[x] {
+		t.Errorf("Bad search result %d, %v", x, ok)
+	}
+	_, ok = BBHTSearch(newTestBitFlipOracle(3), BitFlipOracle, nil,
+		func(x int) bool { return false })
+	if ok {
+		t.Error("Found a solution where there is none")
+	}
+	// A 1-qubit bit-flip oracle has no input qubits
+	for _, want := range []bool{false, true} {
+		x, ok = BBHTSearch(newTestBitFlipOracle(0), BitFlipOracle, nil,
+			func(x int) bool { return want })
+		if x != 0 || ok != want {
+			t.Errorf("Bad search result %d, %v with no input qubits",
+				x, ok)
+		}
+	}
+}
+
+func TestGroverGate(t *testing.T) {
+	oracle := newTestPhaseOracle(3, 6)
+	grover := NewGroverGate(oracle, PhaseOracle, nil)
+	if !grover.IsUnitary() {
+		t.Fatal("Grover gate is not unitary")
+	}
+	// Two applications to the uniform superposition match two iterations
+	// of amplitude amplification
+	qreg := NewQReg(3, 0)
+	HadamardReg(qreg)
+	grover.ApplyReg(qreg)
+	grover.ApplyReg(qreg)
+	if !qreg.EqualsUpToPhase(AmplitudeAmplification(oracle, PhaseOracle, nil, 2)) {
+		t.Error("Grover gate does not match amplitude amplification")
+	}
+}
+
+func TestQuantumCounting(t *testing.T) {
+	for _, k := range []int{0, 1, 3, 4, 6} {
+		marked := make([]int, k)
+		for i := range marked {
+			marked[i] = i + 1
+		}
+		oracle := newTestBitFlipOracle(3, marked...)
+		estimate, lower, upper := QuantumCounting(oracle,
+			BitFlipOracle, 5)
+		if lower > estimate || estimate > upper {
+			t.Errorf("Estimate %f outside its bounds [%f, %f]",
+				estimate, lower, upper)
+		}
+		// The eigenphase is exact for 0 and 4 solutions among 8
+		if (k == 0 || k == 4) && math.Abs(estimate-float64(k)) > 1e-9 {
+			t.Errorf("Bad estimate %f for %d solutions", estimate, k)
+		}
+	}
+}
+
+func TestQuantumCounting_Bounds(t *testing.T) {
+	for k := 1; k < 8; k++ {
+		marked := make([]int, k)
+		for i := range marked {
+			marked[i] = i
+		}
+		oracle := newTestPhaseOracle(3, marked...)
+		prep := NewHadamardGate(3)
+		probs := PhaseEstimation(NewGroverGate(oracle, PhaseOracle, nil),
+			prep, 5)
+		// The bounds must hold with probability at least 8/pi**2
+		held := float64(0.0)
+		for j, prob := range probs {
+			_, lower, upper := countFromPhase(float64(j)/32, 8, 32)
+			if lower <= float64(k)+1e-9 && float64(k) <= upper+1e-9 {
+				held += prob
+			}
+		}
+		if held < 8/(math.Pi*math.Pi) {
+			t.Errorf("Bounds hold with probability %f for %d "+
+				"solutions", held, k)
+		}
+	}
+}
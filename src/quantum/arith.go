@@ -0,0 +1,365 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// Reversible arithmetic circuits.  An integer is held by a list of qubits,
+// with qubits[i] as bit i, or by a sub-register in the forms ending in Sub.
+// Every construction takes optional control qubits and acts only when all of
+// them are 1.  Ancilla qubits must start in |0> and are returned to |0>.
+
+import (
+	"fmt"
+	"math"
+)
+
+// Get the given qubits followed by more qubits, in a new list
+func withQubits(qubits []int, more ...int) []int {
+	return append(append([]int{}, qubits...), more...)
+}
+
+// Append a gate controlled by the given qubits
+func (c *Circuit) applyControlled(gate *Gate, controls []int, qubits ...int) {
+	if len(controls) > 0 {
+		gate = NewControlledGate(gate, len(controls))
+	}
+	c.Apply(gate, withQubits(controls, qubits...)...)
+}
+
+// Append the operations of another circuit of the same width, in reverse
+// order if reverse is set.  Reversing inverts a circuit only when each of its
+// gates is its own inverse.
+func (c *Circuit) appendOps(other *Circuit, reverse bool) {
+	for i := range other.ops {
+		if reverse {
+			i = len(other.ops) - 1 - i
+		}
+		op := *other.ops[i]
+		c.add(&op)
+	}
+}
+
+// Get the greatest common divisor of a and b
+func GCD(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Get the inverse of a modulo m
+func modInverse(a int, m int) int {
+	// Extended Euclid, keeping r = s a (mod m) for each remainder
+	r0, r1 := m, ((a%m)+m)%m
+	s0, s1 := 0, 1
+	for r1 != 0 {
+		q := r0 / r1
+		r0, r1 = r1, r0-q*r1
+		s0, s1 = s1, s0-q*s1
+	}
+	if r0 != 1 {
+		panic(fmt.Sprintf("%d has no inverse modulo %d", a, m))
+	}
+	return ((s0 % m) + m) % m
+}
+
+// Ripple-carry arithmetic (Cuccaro, Draper, Kutin and Moulton)
+
+// Append MAJ, which leaves the majority of x, y and z, the carry, in z
+func (c *Circuit) maj(x int, y int, z int, controls []int) {
+	c.applyControlled(NewPauliXGate(), withQubits(controls, z), y)
+	c.applyControlled(NewPauliXGate(), withQubits(controls, z), x)
+	c.applyControlled(NewPauliXGate(), withQubits(controls, x, y), z)
+}
+
+// Append UMA, which undoes MAJ and leaves the sum bit in y
+func (c *Circuit) uma(x int, y int, z int, controls []int) {
+	c.applyControlled(NewPauliXGate(), withQubits(controls, x, y), z)
+	c.applyControlled(NewPauliXGate(), withQubits(controls, z), x)
+	c.applyControlled(NewPauliXGate(), withQubits(controls, x), y)
+}
+
+// Make the chain of MAJ gates that leaves the carry out of a + b in the last
+// qubit of a
+func (c *Circuit) majChain(a []int, b []int, ancilla int, controls []int) *Circuit {
+	if len(a) != len(b) || len(a) == 0 {
+		panic(fmt.Sprintf("Cannot add %d-qubit and %d-qubit integers",
+			len(a), len(b)))
+	}
+	chain := NewCircuit(c.width)
+	carry := ancilla
+	for i := range a {
+		chain.maj(carry, b[i], a[i], controls)
+		carry = a[i]
+	}
+	return chain
+}
+
+// Append the ripple-carry adder, or its inverse, with an optional carry qubit
+func (c *Circuit) cuccaro(a []int, b []int, ancilla int, carry int, controls []int, reverse bool) {
+	n := len(a)
+	adder := NewCircuit(c.width)
+	adder.appendOps(c.majChain(a, b, ancilla, controls), false)
+	if carry >= 0 {
+		adder.applyControlled(NewPauliXGate(), withQubits(controls, a[n-1]),
+			carry)
+	}
+	for i := n - 1; i >= 0; i-- {
+		in := ancilla
+		if i > 0 {
+			in = a[i-1]
+		}
+		adder.uma(in, b[i], a[i], controls)
+	}
+	c.appendOps(adder, reverse)
+}
+
+// Append the ripple-carry adder b += a mod 2**len(b).  It needs one ancilla.
+func (c *Circuit) Add(a []int, b []int, ancilla int, controls ...int) {
+	c.cuccaro(a, b, ancilla, -1, controls, false)
+}
+
+// Append the ripple-carry adder b += a mod 2**len(b), flipping the carry
+// qubit if the sum overflows
+func (c *Circuit) AddWithCarry(a []int, b []int, ancilla int, carry int, controls ...int) {
+	c.cuccaro(a, b, ancilla, carry, controls, false)
+}
+
+// Append the ripple-carry subtractor b -= a mod 2**len(b)
+func (c *Circuit) Subtract(a []int, b []int, ancilla int, controls ...int) {
+	c.cuccaro(a, b, ancilla, -1, controls, true)
+}
+
+// Append the comparator that flips the result qubit if a > b.  Only the
+// final flip is controlled, since the rest of the circuit undoes itself.
+func (c *Circuit) Compare(a []int, b []int, ancilla int, result int, controls ...int) {
+	// The carry out of a + (2**n - 1 - b) is set exactly when a > b
+	flip := NewCircuit(c.width)
+	for _, qubit := range b {
+		flip.Apply(NewPauliXGate(), qubit)
+	}
+	chain := NewCircuit(c.width)
+	chain.appendOps(flip, false)
+	chain.appendOps(c.majChain(a, b, ancilla, nil), false)
+	c.appendOps(chain, false)
+	c.applyControlled(NewPauliXGate(), withQubits(controls, a[len(a)-1]),
+		result)
+	c.appendOps(chain, true)
+}
+
+// Fourier-space arithmetic (Draper)
+
+// Append the phases that add a 2**shift to an integer held in Fourier space by
+// b, that is, after Circuit.QFT, or subtract it for a sign of -1
+func (c *Circuit) phiAdd(a []int, b []int, shift int, sign float64, controls []int) {
+	n := len(b)
+	for i, a_qubit := range a {
+		for j := 0; shift+i+j < n; j++ {
+			angle := sign * 2 * math.Pi /
+				float64(int(1)<<uint(n-shift-i-j))
+			c.applyControlled(NewPhaseShiftGate(angle),
+				withQubits(controls, a_qubit), b[j])
+		}
+	}
+}
+
+// Append the phases that add a classical constant to an integer held in
+// Fourier space by b
+func (c *Circuit) phiAddConstant(b []int, constant int, controls []int) {
+	n := len(b)
+	width := 1 << uint(n)
+	term := ((constant % width) + width) % width
+	for _, qubit := range b {
+		if term != 0 {
+			angle := 2 * math.Pi * float64(term) / float64(width)
+			c.applyControlled(NewPhaseShiftGate(angle), controls, qubit)
+		}
+		term = term * 2 % width
+	}
+}
+
+// Append the Draper adder b += a mod 2**len(b), which needs no ancilla.  a
+// may have fewer qubits than b.
+func (c *Circuit) DraperAdd(a []int, b []int, controls ...int) {
+	c.QFT(b...)
+	c.phiAdd(a, b, 0, 1, controls)
+	c.InverseQFT(b...)
+}
+
+// Append the Draper subtractor b -= a mod 2**len(b)
+func (c *Circuit) DraperSubtract(a []int, b []int, controls ...int) {
+	c.QFT(b...)
+	c.phiAdd(a, b, 0, -1, controls)
+	c.InverseQFT(b...)
+}
+
+// Append the adder b += constant mod 2**len(b) for a classical constant
+func (c *Circuit) AddConstant(b []int, constant int, controls ...int) {
+	c.QFT(b...)
+	c.phiAddConstant(b, constant, controls)
+	c.InverseQFT(b...)
+}
+
+// Append the multiplier product += a b mod 2**len(product), which needs no
+// ancilla.  The three registers must not share qubits.  Each qubit of a controls the Fourier-space addition of b shifted
+// by its weight.
+func (c *Circuit) Multiply(a []int, b []int, product []int, controls ...int) {
+	c.QFT(product...)
+	for i, qubit := range a {
+		c.phiAdd(b, product, i, 1, withQubits(controls, qubit))
+	}
+	c.InverseQFT(product...)
+}
+
+// Modular arithmetic (Beauregard)
+
+func checkModulus(b []int, modulus int) {
+	if modulus < 1 || len(b) < 2 || modulus > 1<<uint(len(b)-1) {
+		panic(fmt.Sprintf("Modulus %d needs a register of more than %d "+
+			"qubits", modulus, len(b)))
+	}
+}
+
+// Append b = (b + constant) mod modulus on an integer held in Fourier space
+// by b, whose top qubit absorbs the overflow
+func (c *Circuit) phiModAddConstant(b []int, constant int, modulus int, ancilla int, controls []int) {
+	constant = ((constant % modulus) + modulus) % modulus
+	top := b[len(b)-1]
+	c.phiAddConstant(b, constant, controls)
+	c.phiAddConstant(b, -modulus, nil)
+	// The top qubit is now set if b + constant < modulus
+	c.InverseQFT(b...)
+	c.applyControlled(NewPauliXGate(), []int{top}, ancilla)
+	c.QFT(b...)
+	c.phiAddConstant(b, modulus, []int{ancilla})
+	// Subtracting the constant again goes negative exactly when the sum
+	// wrapped around, which is when the ancilla must be cleared
+	c.phiAddConstant(b, -constant, controls)
+	c.InverseQFT(b...)
+	c.Apply(NewPauliXGate(), top)
+	c.applyControlled(NewPauliXGate(), []int{top}, ancilla)
+	c.Apply(NewPauliXGate(), top)
+	c.QFT(b...)
+	c.phiAddConstant(b, constant, controls)
+}
+
+// Append the modular adder b = (b + constant) mod modulus for b < modulus.
+// The top qubit of b must be 0 and modulus must fit in the others.  It needs
+// one ancilla.
+func (c *Circuit) ModAddConstant(b []int, constant int, modulus int, ancilla int, controls ...int) {
+	checkModulus(b, modulus)
+	c.QFT(b...)
+	c.phiModAddConstant(b, constant, modulus, ancilla, controls)
+	c.InverseQFT(b...)
+}
+
+// Append the modular multiply-accumulate b = (b + constant x) mod modulus for
+// b < modulus, with b laid out as for ModAddConstant
+func (c *Circuit) ModMultiplyAdd(x []int, b []int, constant int, modulus int, ancilla int, controls ...int) {
+	checkModulus(b, modulus)
+	c.QFT(b...)
+	term := ((constant % modulus) + modulus) % modulus
+	for _, qubit := range x {
+		c.phiModAddConstant(b, term, modulus, ancilla,
+			withQubits(controls, qubit))
+		term = term * 2 % modulus
+	}
+	c.InverseQFT(b...)
+}
+
+// Append the in-place modular multiplier x = constant x mod modulus for
+// x < modulus.  The constant must be invertible modulo modulus.  It needs
+// len(x) + 1 scratch qubits and one ancilla.
+func (c *Circuit) ModMultiply(x []int, scratch []int, constant int, modulus int, ancilla int, controls ...int) {
+	if len(scratch) != len(x)+1 {
+		panic(fmt.Sprintf("%d-qubit multiplier needs %d scratch qubits, "+
+			"not %d", len(x), len(x)+1, len(scratch)))
+	}
+	inverse := modInverse(constant, modulus)
+	c.ModMultiplyAdd(x, scratch, constant, modulus, ancilla, controls...)
+	for i, qubit := range x {
+		c.applyControlled(NewSwapGate(), controls, qubit, scratch[i])
+	}
+	// Clear the scratch qubits, which now hold x, by subtracting
+	// constant**-1 (constant x)
+	c.ModMultiplyAdd(x, scratch, modulus-inverse, modulus, ancilla,
+		controls...)
+}
+
+// Sub-register forms
+
+// Append the ripple-carry adder b += a on sub-registers, as Add
+func (c *Circuit) AddSub(a *SubReg, b *SubReg, ancilla int, controls ...int) {
+	c.Add(a.Qubits(), b.Qubits(), ancilla, controls...)
+}
+
+// Append the ripple-carry adder b += a on sub-registers, as AddWithCarry
+func (c *Circuit) AddWithCarrySub(a *SubReg, b *SubReg, ancilla int, carry int, controls ...int) {
+	c.AddWithCarry(a.Qubits(), b.Qubits(), ancilla, carry, controls...)
+}
+
+// Append the ripple-carry subtractor b -= a on sub-registers, as Subtract
+func (c *Circuit) SubtractSub(a *SubReg, b *SubReg, ancilla int, controls ...int) {
+	c.Subtract(a.Qubits(), b.Qubits(), ancilla, controls...)
+}
+
+// Append the comparator of sub-registers a > b, as Compare
+func (c *Circuit) CompareSub(a *SubReg, b *SubReg, ancilla int, result int, controls ...int) {
+	c.Compare(a.Qubits(), b.Qubits(), ancilla, result, controls...)
+}
+
+// Append the Draper adder b += a on sub-registers, as DraperAdd
+func (c *Circuit) DraperAddSub(a *SubReg, b *SubReg, controls ...int) {
+	c.DraperAdd(a.Qubits(), b.Qubits(), controls...)
+}
+
+// Append the Draper subtractor b -= a on sub-registers, as DraperSubtract
+func (c *Circuit) DraperSubtractSub(a *SubReg, b *SubReg, controls ...int) {
+	c.DraperSubtract(a.Qubits(), b.Qubits(), controls...)
+}
+
+// Append the adder b += constant on a sub-register, as AddConstant
+func (c *Circuit) AddConstantSub(b *SubReg, constant int, controls ...int) {
+	c.AddConstant(b.Qubits(), constant, controls...)
+}
+
+// Append the multiplier product += a b on sub-registers, as Multiply
+func (c *Circuit) MultiplySub(a *SubReg, b *SubReg, product *SubReg, controls ...int) {
+	c.Multiply(a.Qubits(), b.Qubits(), product.Qubits(), controls...)
+}
+
+// Append the modular adder b = (b + constant) mod modulus on a sub-register,
+// as ModAddConstant
+func (c *Circuit) ModAddConstantSub(b *SubReg, constant int, modulus int, ancilla int, controls ...int) {
+	c.ModAddConstant(b.Qubits(), constant, modulus, ancilla, controls...)
+}
+
+// Append the modular multiply-accumulate b = (b + constant x) mod modulus on
+// sub-registers, as ModMultiplyAdd
+func (c *Circuit) ModMultiplyAddSub(x *SubReg, b *SubReg, constant int, modulus int, ancilla int, controls ...int) {
+	c.ModMultiplyAdd(x.Qubits(), b.Qubits(), constant, modulus, ancilla,
+		controls...)
+}
+
+// Append the modular multiplier x = constant x mod modulus on
+// sub-registers, as ModMultiply
+func (c *Circuit) ModMultiplySub(x *SubReg, scratch *SubReg, constant int, modulus int, ancilla int, controls ...int) {
+	c.ModMultiply(x.Qubits(), scratch.Qubits(), constant, modulus, ancilla,
+		controls...)
+}
@@ -0,0 +1,274 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"testing"
+)
+
+// Helper function for testing. Runs a circuit on a basis state and returns
+// the basis state it produces, failing if the result is not a basis state.
+func runBasisState(t *testing.T, c *Circuit, state int) int {
+	qreg := NewQReg(c.Width(), state)
+	c.Run(qreg)
+	for out := range qreg.amplitudes {
+		if math.Abs(qreg.StateProb(out)-1) < 1e-9 {
+			return out
+		}
+	}
+	t.Fatalf("Circuit did not map state %d to a basis state", state)
+	return 0
+}
+
+func TestAdd(t *testing.T) {
+	a, b := []int{0, 1, 2}, []int{3, 4, 5}
+	ancilla, carry, control := 6, 7, 8
+	add := NewCircuit(9)
+	add.AddWithCarry(a, b, ancilla, carry, control)
+	sub := NewCircuit(9)
+	sub.Subtract(a, b, ancilla, control)
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			for on := 0; on < 2; on++ {
+				in := spreadValue(x, a) | spreadValue(y, b) |
+					on<<uint(control)
+				sum, diff, over := y, y, 0
+				if on == 1 {
+					sum, diff, over = (x+y)%8, (y-x+8)%8, (x+y)/8
+				}
+				want := spreadValue(x, a) | spreadValue(sum, b) |
+					over<<uint(carry) | on<<uint(control)
+				if out := runBasisState(t, add, in); out != want {
+					t.Errorf("Bad sum of %d and %d = %d, want %d",
+						x, y, subValue(out, b), sum)
+				}
+				want = spreadValue(x, a) | spreadValue(diff, b) |
+					on<<uint(control)
+				if out := runBasisState(t, sub, in); out != want {
+					t.Errorf("Bad difference %d - %d = %d, want %d",
+						y, x, subValue(out, b), diff)
+				}
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a, b := []int{0, 1, 2}, []int{3, 4, 5}
+	ancilla, result := 6, 7
+	c := NewCircuit(8)
+	c.Compare(a, b, ancilla, result)
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			in := spreadValue(x, a) | spreadValue(y, b)
+			want := in
+			if x > y {
+				want |= 1 << uint(result)
+			}
+			if out := runBasisState(t, c, in); out != want {
+				t.Errorf("Bad comparison of %d and %d = %d, want %d",
+					x, y, out, want)
+			}
+		}
+	}
+}
+
+func TestDraperAdd(t *testing.T) {
+	a, b, control := []int{0, 1}, []int{2, 3, 4}, 5
+	add := NewCircuit(6)
+	add.DraperAdd(a, b, control)
+	sub := NewCircuit(6)
+	sub.DraperSubtract(a, b)
+	constant := NewCircuit(6)
+	constant.AddConstant(b, 11, control)
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 8; y++ {
+			for on := 0; on < 2; on++ {
+				in := spreadValue(x, a) | spreadValue(y, b) |
+					on<<uint(control)
+				sum, plus := y, y
+				if on == 1 {
+					sum, plus = (x+y)%8, (y+11)%8
+				}
+				out := runBasisState(t, add, in)
+				if out != in&^spreadValue(7, b)|spreadValue(sum, b) {
+					t.Errorf("Bad sum of %d and %d = %d, want %d",
+						x, y, subValue(out, b), sum)
+				}
+				out = runBasisState(t, constant, in)
+				if out != in&^spreadValue(7, b)|spreadValue(plus, b) {
+					t.Errorf("Bad sum of %d and 11 = %d, want %d",
+						y, subValue(out, b), plus)
+				}
+			}
+			in := spreadValue(x, a) | spreadValue(y, b)
+			diff := (y - x + 8) % 8
+			if out := runBasisState(t, sub, in); out !=
+				spreadValue(x, a)|spreadValue(diff, b) {
+				t.Errorf("Bad difference %d - %d = %d, want %d",
+					y, x, subValue(out, b), diff)
+			}
+		}
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	a, b, product := []int{0, 1}, []int{2, 3, 4}, []int{5, 6, 7, 8}
+	control := 9
+	c := NewCircuit(10)
+	c.Multiply(a, b, product, control)
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 8; y++ {
+			for p := 0; p < 16; p += 5 {
+				for on := 0; on < 2; on++ {
+					in := spreadValue(x, a) | spreadValue(y, b) |
+						spreadValue(p, product) |
+						on<<uint(control)
+					want := p
+					if on == 1 {
+						want = (p + x*y) % 16
+					}
+					out := runBasisState(t, c, in)
+					if out != in&^spreadValue(15, product)|
+						spreadValue(want, product) {
+						t.Errorf("Bad %d + %d * %d = %d, "+
+							"want %d", p, x, y,
+							subValue(out, product), want)
+					}
+				}
+			}
+		}
+	}
+
+	qreg := NewQReg(10, spreadValue(3, a)|spreadValue(6, b))
+	c = NewCircuit(10)
+	c.MultiplySub(qreg.DefineSub("a", a...), qreg.DefineSub("b", b...),
+		qreg.DefineSub("product", product...))
+	c.Run(qreg)
+	if p := qreg.Sub("product").Measure(); p != 2 {
+		t.Errorf("Bad sub-register product 3 * 6 mod 16 = %d, want 2",
+			p)
+	}
+}
+
+func TestModAddConstant(t *testing.T) {
+	modulus := 5
+	b, ancilla, control := []int{0, 1, 2, 3}, 4, 5
+	for constant := 0; constant < modulus; constant++ {
+		c := NewCircuit(6)
+		c.ModAddConstant(b, constant, modulus, ancilla, control)
+		for y := 0; y < modulus; y++ {
+			for on := 0; on < 2; on++ {
+				in := spreadValue(y, b) | on<<uint(control)
+				sum := y
+				if on == 1 {
+					sum = (y + constant) % modulus
+				}
+				want := spreadValue(sum, b) | on<<uint(control)
+				if out := runBasisState(t, c, in); out != want {
+					t.Errorf("Bad sum of %d and %d mod %d = %d, "+
+						"want %d", y, constant, modulus,
+						subValue(out, b), sum)
+				}
+			}
+		}
+	}
+}
+
+func TestModMultiplyAdd(t *testing.T) {
+	modulus, constant := 5, 3
+	x, b, ancilla := []int{0, 1, 2}, []int{3, 4, 5, 6}, 7
+	c := NewCircuit(8)
+	c.ModMultiplyAdd(x, b, constant, modulus, ancilla)
+	for v := 0; v < 8; v++ {
+		for y := 0; y < modulus; y++ {
+			in := spreadValue(v, x) | spreadValue(y, b)
+			sum := (y + constant*v) % modulus
+			want := spreadValue(v, x) | spreadValue(sum, b)
+			if out := runBasisState(t, c, in); out != want {
+				t.Errorf("Bad %d + %d * %d mod %d = %d, want %d",
+					y, constant, v, modulus, subValue(out, b), sum)
+			}
+		}
+	}
+}
+
+func TestModMultiply(t *testing.T) {
+	modulus := 7
+	x, scratch := []int{0, 1, 2}, []int{3, 4, 5, 6}
+	ancilla, control := 7, 8
+	for _, constant := range []int{2, 3} {
+		c := NewCircuit(9)
+		c.ModMultiply(x, scratch, constant, modulus, ancilla, control)
+		for v := 0; v < modulus; v++ {
+			for on := 0; on < 2; on++ {
+				in := spreadValue(v, x) | on<<uint(control)
+				product := v
+				if on == 1 {
+					product = constant * v % modulus
+				}
+				want := spreadValue(product, x) | on<<uint(control)
+				if out := runBasisState(t, c, in); out != want {
+					t.Errorf("Bad %d * %d mod %d = %d, want %d",
+						constant, v, modulus, out, want)
+				}
+			}
+		}
+	}
+}
+
+func TestArithSub(t *testing.T) {
+	qreg := NewQReg(8, 0)
+	a := qreg.DefineSubRange("a", 0, 3)
+	b := qreg.DefineSubRange("b", 4, 7)
+	ancilla, result := 3, 7
+	c := NewCircuit(8)
+	c.AddConstantSub(a, 5)
+	c.AddConstantSub(b, 6)
+	c.AddSub(a, b, ancilla)
+	c.DraperSubtractSub(a, b)
+	c.DraperAddSub(b, a)
+	c.CompareSub(a, b, ancilla, result)
+	c.Run(qreg)
+	// b = 6 + 5 - 5, then a = 5 + 6 mod 8
+	if x, y := a.Measure(), b.Measure(); x != 3 || y != 6 {
+		t.Errorf("Bad sub-register arithmetic a = %d, b = %d, want 3, 6",
+			x, y)
+	}
+	if qreg.BMeasure(result) != 0 {
+		t.Error("Bad sub-register comparison 3 > 6")
+	}
+}
+
+func TestGCD(t *testing.T) {
+	for _, test := range [][3]int{{12, 18, 6}, {7, 0, 7}, {0, 5, 5}, {-4, 6, 2}} {
+		if d := GCD(test[0], test[1]); d != test[2] {
+			t.Errorf("Bad GCD(%d, %d) = %d, want %d", test[0],
+				test[1], d, test[2])
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	if i := modInverse(3, 7); i != 5 {
+		t.Errorf("Bad inverse of 3 mod 7 = %d, want 5", i)
+	}
+	if i := modInverse(-2, 9); i != 4 {
+		t.Errorf("Bad inverse of -2 mod 9 = %d, want 4", i)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Get the Bloch vector (x, y, z) of the reduced state of a qubit.  The vector
+// has length 1 when the qubit is in a pure state and is shorter when it is
+// entangled with the rest of the register.
+func (qreg *QReg) BlochVector(index int) (float64, float64, float64) {
+	// rho = (I + xX + yY + zZ) / 2, so rho[1][0] = (x + iy) / 2
+	rho := qreg.PartialTrace([]int{index})
+	off := rho.Get(1, 0)
+	x := 2 * real(off)
+	y := 2 * imag(off)
+	z := real(rho.Get(0, 0) - rho.Get(1, 1))
+	return x, y, z
+}
+
+// Print the Bloch vector and its length for each qubit of the register, in
+// the same style as Print
+func (qreg *QReg) PrintBloch() {
+	padding := int(math.Floor(math.Log10(float64(qreg.width)))) + 1
+	format := fmt.Sprintf("q%%%dd:(%%+f,%%+f,%%+f)%%f\n", padding)
+	for index := 0; index < qreg.width; index++ {
+		x, y, z := qreg.BlochVector(index)
+		fmt.Printf(format, index, x, y, z, math.Sqrt(x*x+y*y+z*z))
+	}
+}
+
+// Print the register as Print does, followed by the Bloch vector of each
+// qubit as PrintBloch does
+func (qreg *QReg) PrintWithBloch() {
+	qreg.Print()
+	qreg.PrintBloch()
+}
+
+// Write the Bloch vector and its length for each qubit of the register as CSV
+func (qreg *QReg) WriteBlochCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"qubit", "x", "y", "z", "length"}); err != nil {
+		return err
+	}
+	for index := 0; index < qreg.width; index++ {
+		x, y, z := qreg.BlochVector(index)
+		record := []string{strconv.Itoa(index)}
+		for _, v := range []float64{x, y, z, math.Sqrt(x*x + y*y + z*z)} {
+			record = append(record, strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
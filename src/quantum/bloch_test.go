@@ -0,0 +1,96 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"testing"
+)
+
+// Helper function for testing. Captures what f prints to standard output.
+func captureStdout(t *testing.T, f func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	f()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestBlochVector(t *testing.T) {
+	qreg := NewQReg(3, 4)
+	Hadamard(qreg, 0)
+	// Rotate qubit 1 to (|0> + i|1>)/sqrt(2)
+	Hadamard(qreg, 1)
+	NewArrayGate([]complex128{1, 0, 0, complex(0, 1)}).Apply(qreg, []int{1})
+	want := [][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, -1}}
+	for index, v := range want {
+		x, y, z := qreg.BlochVector(index)
+		if math.Abs(x-v[0]) > 1e-9 || math.Abs(y-v[1]) > 1e-9 ||
+			math.Abs(z-v[2]) > 1e-9 {
+			t.Errorf("Bad Bloch vector for qubit %d = (%f, %f, %f), "+
+				"want %v", index, x, y, z, v)
+		}
+	}
+}
+
+func TestBlochVector_Entangled(t *testing.T) {
+	qreg := newBellQReg(2, 0, 1)
+	x, y, z := qreg.BlochVector(0)
+	if length := math.Sqrt(x*x + y*y + z*z); length > 1e-9 {
+		t.Errorf("Bad Bloch vector length for Bell state half = %f, "+
+			"want 0", length)
+	}
+}
+
+func TestWriteBlochCSV(t *testing.T) {
+	qreg := NewQReg(2, 1)
+	var buf bytes.Buffer
+	if err := qreg.WriteBlochCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "qubit,x,y,z,length\n0,0,0,-1,1\n1,0,0,1,1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Bad CSV output %q, want %q", got, want)
+	}
+}
+
+func TestPrintWithBloch(t *testing.T) {
+	qreg := NewQReg(2, 1)
+	Hadamard(qreg, 1)
+	want := `(+0.000000+0.000000i)0.000000|(0)00>
+(+0.707107+0.000000i)0.500000|(1)01>
+(+0.000000+0.000000i)0.000000|(2)10>
+(+0.707107+0.000000i)0.500000|(3)11>
+q0:(+0.000000,+0.000000,-1.000000)1.000000
+q1:(+1.000000,+0.000000,+0.000000)1.000000
+`
+	if got := captureStdout(t, qreg.PrintWithBloch); got != want {
+		t.Errorf("Bad output:\n%s\nwant:\n%s", got, want)
+	}
+}
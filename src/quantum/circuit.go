@@ -0,0 +1,498 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/cmplx"
+)
+
+type opKind int
+
+const (
+	gateOp opKind = iota
+	measureOp
+	resetOp
+	barrierOp
+)
+
+var opKindNames = map[opKind]string{
+	gateOp:    "gate",
+	measureOp: "measure",
+	resetOp:   "reset",
+	barrierOp: "barrier",
+}
+
+// A condition on the classical bits of a circuit.  It holds when the bits,
+// read as an integer with clbits[i] as bit i, equal value.
+type condition struct {
+	clbits []string
+	value  int
+}
+
+// Represents one step of a circuit: a gate application, a measurement, a reset
+// or a barrier, optionally conditioned on classical bits
+type Operation struct {
+	kind      opKind
+	gate      *Gate
+	qubits    []int
+	clbit     string
+	condition *condition
+
+	// The circuit the operation belongs to, used to check conditions.
+	circuit *Circuit
+}
+
+// Represents a sequence of operations on a quantum register of a fixed width
+// and on a set of named classical bits
+type Circuit struct {
+	// The width (number of qubits) of the register the circuit acts on.
+	width int
+
+	// The names of the classical bits, in the order they were added.
+	clbits []string
+
+	ops []*Operation
+}
+
+// Constructor for a Circuit
+func NewCircuit(width int, clbits ...string) *Circuit {
+	c := &Circuit{width: width}
+	c.AddClbits(clbits...)
+	return c
+}
+
+// Accessor for the width of a Circuit
+func (c *Circuit) Width() int {
+	return c.width
+}
+
+// Accessor for the names of the classical bits of a Circuit
+func (c *Circuit) Clbits() []string {
+	clbits := make([]string, len(c.clbits))
+	copy(clbits, c.clbits)
+	return clbits
+}
+
+func (c *Circuit) hasClbit(name string) bool {
+	for _, clbit := range c.clbits {
+		if clbit == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Add classical bits to the circuit
+func (c *Circuit) AddClbits(names ...string) {
+	for _, name := range names {
+		if c.hasClbit(name) {
+			panic(fmt.Sprintf("Classical bit %q is already defined",
+				name))
+		}
+		c.clbits = append(c.clbits, name)
+	}
+}
+
+// Accessor for the operations of a Circuit
+func (c *Circuit) Operations() []*Operation {
+	ops := make([]*Operation, len(c.ops))
+	copy(ops, c.ops)
+	return ops
+}
+
+// Check that an operation fits the circuit
+func (c *Circuit) check(op *Operation) error {
+	seen := make(map[int]bool)
+	for _, qubit := range op.qubits {
+		if qubit < 0 || qubit >= c.width {
+			return fmt.Errorf("%d is not a valid target", qubit)
+		}
+		if seen[qubit] {
+			return fmt.Errorf("Qubit %d appears twice", qubit)
+		}
+		seen[qubit] = true
+	}
+	switch op.kind {
+	case gateOp:
+		if op.gate.bits() != len(op.qubits) {
+			return fmt.Errorf("%d-bit gate applied to %d qubits",
+				op.gate.bits(), len(op.qubits))
+		}
+	case measureOp:
+		if len(op.qubits) != 1 {
+			return errors.New("Measurement must have one qubit")
+		}
+		if !c.hasClbit(op.clbit) {
+			return fmt.Errorf("No classical bit named %q", op.clbit)
+		}
+	case resetOp:
+		if len(op.qubits) != 1 {
+			return errors.New("Reset must have one qubit")
+		}
+	case barrierOp:
+		if len(op.qubits) == 0 {
+			return errors.New("Barrier must have a qubit")
+		}
+		if op.condition != nil {
+			return errors.New("Barrier cannot be conditioned")
+		}
+	}
+	if op.condition != nil {
+		for _, clbit := range op.condition.clbits {
+			if !c.hasClbit(clbit) {
+				return fmt.Errorf("No classical bit named %q",
+					clbit)
+			}
+		}
+		bits := uint(len(op.condition.clbits))
+		if op.condition.value < 0 || op.condition.value >= 1<<bits {
+			return fmt.Errorf("%d is not a value of %d classical "+
+				"bits", op.condition.value, bits)
+		}
+	}
+	return nil
+}
+
+func (c *Circuit) add(op *Operation) *Operation {
+	if err := c.check(op); err != nil {
+		panic(err.Error())
+	}
+	op.circuit = c
+	c.ops = append(c.ops, op)
+	return op
+}
+
+// Append a gate acting on the given qubits, with qubits[i] as target i
+func (c *Circuit) Apply(gate *Gate, qubits ...int) *Operation {
+	return c.add(&Operation{kind: gateOp, gate: gate, qubits: qubits})
+}
+
+// Append a measurement of a qubit into a classical bit
+func (c *Circuit) Measure(qubit int, clbit string) *Operation {
+	return c.add(&Operation{kind: measureOp, qubits: []int{qubit},
+		clbit: clbit})
+}
+
+// Append a reset of a qubit to |0>
+func (c *Circuit) Reset(qubit int) *Operation {
+	return c.add(&Operation{kind: resetOp, qubits: []int{qubit}})
+}
+
+// Append a barrier across the given qubits, or across every qubit if none are
+// given.  Barriers do nothing when run; they only group operations in
+// diagrams.
+func (c *Circuit) Barrier(qubits ...int) *Operation {
+	if len(qubits) == 0 {
+		qubits = qubitRange(0, c.width)
+	}
+	return c.add(&Operation{kind: barrierOp, qubits: qubits})
+}
+
+// Only perform the operation when the given classical bits, read as an
+// integer with clbits[i] as bit i, equal value.  The operation is returned so
+// that this can be chained onto Circuit.Apply.
+func (op *Operation) CIf(value int, clbits ...string) *Operation {
+	previous := op.condition
+	op.condition = &condition{clbits, value}
+	if err := op.circuit.check(op); err != nil {
+		op.condition = previous
+		panic(err.Error())
+	}
+	return op
+}
+
+// Accessor for the gate of an operation (nil for measurements)
+func (op *Operation) Gate() *Gate {
+	return op.gate
+}
+
+// Accessor for the qubits an operation acts on
+func (op *Operation) Qubits() []int {
+	qubits := make([]int, len(op.qubits))
+	copy(qubits, op.qubits)
+	return qubits
+}
+
+// Run the circuit on a quantum register, starting with every classical bit
+// set to 0, and return the resulting classical bits
+func (c *Circuit) Run(qreg *QReg) *ClassicalReg {
+	creg := NewClassicalReg(c.clbits...)
+	c.RunWith(qreg, creg)
+	return creg
+}
+
+// Run the circuit on a quantum register and a classical register, which must
+// have every classical bit of the circuit
+func (c *Circuit) RunWith(qreg *QReg, creg *ClassicalReg) {
+	if qreg.width != c.width {
+		panic(fmt.Sprintf("%d-qubit circuit run on QReg of width %d",
+			c.width, qreg.width))
+	}
+	for _, op := range c.ops {
+		if op.condition != nil &&
+			creg.Value(op.condition.clbits...) != op.condition.value {
+			continue
+		}
+		switch op.kind {
+		case gateOp:
+			op.gate.Apply(qreg, op.qubits)
+		case measureOp:
+			creg.Set(op.clbit, qreg.BMeasure(op.qubits[0]))
+		case resetOp:
+			qreg.Reset(op.qubits[0])
+		}
+	}
+}
+
+// Get the unitary performed by a circuit of gates as a single gate.  The
+// matrix is computed by running the circuit on every basis state, so this is
+// only practical for small circuits.
+func (c *Circuit) ToGate() *Gate {
+	for _, op := range c.ops {
+		if op.kind == barrierOp {
+			continue
+		}
+		if op.kind != gateOp || op.condition != nil {
+			panic("Only circuits of unconditioned gates are unitary")
+		}
+	}
+	width := 1 << uint(c.width)
+	elements := make([]complex128, width*width)
+	for col := 0; col < width; col++ {
+		qreg := NewQReg(c.width, col)
+		c.Run(qreg)
+		for row, amp := range qreg.amplitudes {
+			elements[row*width+col] = amp
+		}
+	}
+	return NewFuncGateNoCheck(func(row int, col int) complex128 {
+		return elements[row*width+col]
+	},
+		c.width)
+}
+
+// Circuits are serialized to JSON as an object such as
+//   {"version":1,"width":1,"clbits":["m"],"operations":[
+//     {"op":"gate","qubits":[0],"name":"H","matrix":[[0.7,0],...]},
+//     {"op":"measure","qubits":[0],"clbit":"m"},
+//     {"op":"reset","qubits":[0]},
+//     {"op":"barrier","qubits":[0]},
+//     {"op":"gate","qubits":[0],"name":"X","permutation":[1,0],
+//      "condition":{"clbits":["m"],"value":1}},
+//     {"op":"gate","qubits":[0,1],"name":"P","controls":1,"params":[0.5],
+//      "phase_params":true,"diagonal":[[1,0],[1,0],[1,0],[0.9,0.5]]}]}
+// where a gate is given by its permutation table, by its diagonal, or
+// otherwise by its matrix in row-major order.  Complex numbers are
+// [real, imaginary] pairs.
+
+const circuitFormatVersion = 1
+
+type conditionJSON struct {
+	Clbits []string `json:"clbits"`
+	Value  int      `json:"value"`
+}
+
+type operationJSON struct {
+	Op          string         `json:"op"`
+	Qubits      []int          `json:"qubits"`
+	Name        string         `json:"name,omitempty"`
+	Controls    int            `json:"controls,omitempty"`
+	Params      []float64      `json:"params,omitempty"`
+	PhaseParams bool           `json:"phase_params,omitempty"`
+	Permutation []int          `json:"permutation,omitempty"`
+	Diagonal    [][2]float64   `json:"diagonal,omitempty"`
+	Matrix      [][2]float64   `json:"matrix,omitempty"`
+	Clbit       string         `json:"clbit,omitempty"`
+	Condition   *conditionJSON `json:"condition,omitempty"`
+}
+
+type circuitJSON struct {
+	Version    int             `json:"version"`
+	Width      int             `json:"width"`
+	Clbits     []string        `json:"clbits"`
+	Operations []operationJSON `json:"operations"`
+}
+
+// Encode the circuit as JSON
+func (c *Circuit) MarshalJSON() ([]byte, error) {
+	encoded := circuitJSON{circuitFormatVersion, c.width, c.Clbits(),
+		make([]operationJSON, len(c.ops))}
+	for i, op := range c.ops {
+		op_json := operationJSON{Op: opKindNames[op.kind],
+			Qubits: op.qubits, Clbit: op.clbit}
+		if gate := op.gate; gate != nil {
+			op_json.Name = gate.Name()
+			op_json.Controls = gate.controls
+			op_json.Params = gate.params
+			op_json.PhaseParams = gate.phaseParams
+			switch {
+			case gate.permutation != nil:
+				op_json.Permutation = gate.permutation
+			case gate.diagonal != nil:
+				op_json.Diagonal = complexPairs(gate.diagonal)
+			default:
+				op_json.Matrix = complexPairs(gate.elements())
+			}
+		}
+		if op.condition != nil {
+			op_json.Condition = &conditionJSON{op.condition.clbits,
+				op.condition.value}
+		}
+		encoded.Operations[i] = op_json
+	}
+	return json.Marshal(encoded)
+}
+
+// Decode a circuit from JSON, replacing the circuit's contents
+func (c *Circuit) UnmarshalJSON(data []byte) error {
+	var decoded circuitJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version != circuitFormatVersion {
+		return fmt.Errorf("quantum: unsupported Circuit format version "+
+			"%d", decoded.Version)
+	}
+	result := &Circuit{width: decoded.Width}
+	for _, clbit := range decoded.Clbits {
+		if result.hasClbit(clbit) {
+			return fmt.Errorf("quantum: classical bit %q is defined "+
+				"twice", clbit)
+		}
+		result.clbits = append(result.clbits, clbit)
+	}
+	for _, op_json := range decoded.Operations {
+		op := &Operation{qubits: op_json.Qubits, clbit: op_json.Clbit}
+		switch op_json.Op {
+		case "gate":
+			gate, err := decodeGate(op_json)
+			if err != nil {
+				return err
+			}
+			op.kind = gateOp
+			op.gate = gate
+		case "measure":
+			op.kind = measureOp
+		case "reset":
+			op.kind = resetOp
+		case "barrier":
+			op.kind = barrierOp
+		default:
+			return fmt.Errorf("quantum: unknown operation %q",
+				op_json.Op)
+		}
+		if op_json.Condition != nil {
+			op.condition = &condition{op_json.Condition.Clbits,
+				op_json.Condition.Value}
+		}
+		if err := result.check(op); err != nil {
+			return errors.New("quantum: " + err.Error())
+		}
+		op.circuit = c
+		result.ops = append(result.ops, op)
+	}
+	*c = *result
+	return nil
+}
+
+// Build the gate of an operation from its permutation table, its diagonal or
+// its row-major matrix, along with its label
+func decodeGate(op_json operationJSON) (*Gate, error) {
+	var gate *Gate
+	var err error
+	switch {
+	case op_json.Permutation != nil:
+		gate, err = decodePermutation(op_json.Permutation)
+	case op_json.Diagonal != nil:
+		gate, err = decodeDiagonal(pairsComplex(op_json.Diagonal))
+	default:
+		gate, err = decodeMatrix(pairsComplex(op_json.Matrix))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if op_json.Controls < 0 || op_json.Controls >= gate.bits() {
+		return nil, fmt.Errorf("quantum: %d controls on a %d-bit gate",
+			op_json.Controls, gate.bits())
+	}
+	gate.SetName(op_json.Name)
+	gate.controls = op_json.Controls
+	gate.params = op_json.Params
+	gate.phaseParams = op_json.PhaseParams
+	return gate, nil
+}
+
+// Build a permutation gate from its table
+func decodePermutation(table []int) (*Gate, error) {
+	bits, ok := log2Exact(len(table))
+	if !ok || bits == 0 {
+		return nil, fmt.Errorf("quantum: %d entries is not a "+
+			"permutation table", len(table))
+	}
+	seen := make([]bool, len(table))
+	for _, y := range table {
+		if y < 0 || y >= len(table) || seen[y] {
+			return nil, errors.New("quantum: table is not a " +
+				"permutation")
+		}
+		seen[y] = true
+	}
+	return NewPermutationGate(func(x int) int {
+		return table[x]
+	},
+		bits), nil
+}
+
+// Build a diagonal gate from its diagonal
+func decodeDiagonal(diagonal []complex128) (*Gate, error) {
+	if bits, ok := log2Exact(len(diagonal)); !ok || bits == 0 {
+		return nil, fmt.Errorf("quantum: %d entries is not a gate "+
+			"diagonal", len(diagonal))
+	}
+	for _, d := range diagonal {
+		if !closeEnough(complex(cmplx.Abs(d), 0), 1) {
+			return nil, errors.New("quantum: gate diagonal is not " +
+				"unitary")
+		}
+	}
+	return newDiagonalGateNoCheck(diagonal), nil
+}
+
+// Build a gate from a row-major matrix
+func decodeMatrix(elements []complex128) (*Gate, error) {
+	width := 1
+	bits := 0
+	for width*width < len(elements) {
+		width <<= 1
+		bits++
+	}
+	if width*width != len(elements) || len(elements) < 4 {
+		return nil, fmt.Errorf("quantum: %d elements is not a gate "+
+			"matrix", len(elements))
+	}
+	gate := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		return elements[row*width+col]
+	},
+		bits)
+	if !gate.IsUnitary() {
+		return nil, errors.New("quantum: gate matrix is not unitary")
+	}
+	return gate, nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// Helper function for testing. Builds the teleportation circuit that sends
+// the state of qubit 0 to qubit 2.
+func newTeleportCircuit() *Circuit {
+	cnot := NewClassicalGate(func(x int) int {
+		return x ^ (x&1)<<1
+	}, 2).SetName("CNOT")
+	c := NewCircuit(3, "m0", "m1")
+	c.Apply(NewHadamardGate(1), 1)
+	c.Apply(cnot, 1, 2)
+	c.Apply(cnot, 0, 1)
+	c.Apply(NewHadamardGate(1), 0)
+	c.Measure(0, "m0")
+	c.Measure(1, "m1")
+	c.Apply(NewPauliXGate(), 2).CIf(1, "m1")
+	c.Apply(NewPauliZGate(), 2).CIf(1, "m0")
+	return c
+}
+
+func verifyTeleported(t *testing.T, c *Circuit) {
+	message := NewQReg(1, 0)
+	message.amplitudes[0] = complex(.6, 0)
+	message.amplitudes[1] = complex(0, .8)
+	for i := 0; i < 10; i++ {
+		qreg := message.Tensor(NewQReg(2, 0))
+		c.Run(qreg)
+		received, ok := qreg.SubsystemState([]int{2})
+		if !ok {
+			t.Fatal("Received qubit is still entangled")
+		}
+		if !received.EqualsUpToPhase(message) {
+			t.Fatal("Teleported state does not match the message")
+		}
+	}
+}
+
+func TestCircuitTeleport(t *testing.T) {
+	verifyTeleported(t, newTeleportCircuit())
+}
+
+func TestCircuitCIf(t *testing.T) {
+	c := NewCircuit(2, "a", "b")
+	c.Apply(NewPauliXGate(), 0)
+	c.Measure(0, "a")
+	c.Apply(NewPauliXGate(), 1).CIf(2, "b", "a")
+	c.Measure(1, "b")
+	creg := c.Run(NewQReg(2, 0))
+	if creg.Value("a", "b") != 3 {
+		t.Errorf("Bad classical bits %d, want 3", creg.Value("a", "b"))
+	}
+
+	c = NewCircuit(1, "a")
+	c.Apply(NewPauliXGate(), 0).CIf(1, "a")
+	c.Measure(0, "a")
+	if creg := c.Run(NewQReg(1, 0)); creg.Get("a") != 0 {
+		t.Error("Operation ran although its condition did not hold")
+	}
+}
+
+func TestCircuitCIf_Invalid(t *testing.T) {
+	for _, value := range []int{0, -1, 2} {
+		clbits := []string{"nosuchbit"}
+		if value != 0 {
+			clbits = []string{"m"}
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Accepted condition %d on %v",
+						value, clbits)
+				}
+			}()
+			c := NewCircuit(1, "m")
+			c.Apply(NewPauliXGate(), 0).CIf(value, clbits...)
+		}()
+	}
+}
+
+func TestCircuitJSON(t *testing.T) {
+	data, err := json.Marshal(newTeleportCircuit())
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(Circuit)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Operations()) != 8 {
+		t.Fatalf("Bad number of decoded operations %d, want 8",
+			len(decoded.Operations()))
+	}
+	if name := decoded.Operations()[1].Gate().Name(); name != "CNOT" {
+		t.Errorf("Bad decoded gate name %q, want \"CNOT\"", name)
+	}
+	verifyTeleported(t, decoded)
+
+	bad := `{"version":1,"width":1,"clbits":[],"operations":[` +
+		`{"op":"measure","qubits":[0],"clbit":"m"}]}`
+	if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+		t.Error("Decoded measurement into an undefined classical bit")
+	}
+	bad = `{"version":1,"width":1,"clbits":[],"operations":[` +
+		`{"op":"gate","qubits":[0],"matrix":[[1,0],[1,0],[0,0],[1,0]]}]}`
+	if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+		t.Error("Decoded a non-unitary gate")
+	}
+}
+
+func TestCircuitJSON_GateKinds(t *testing.T) {
+	c := NewCircuit(3)
+	c.Apply(NewHadamardGate(1), 0)
+	c.Apply(NewCNOTGate(), 0, 1)
+	c.Apply(NewControlledGate(NewPhaseShiftGate(.5), 1), 1, 2)
+	c.Apply(NewModExpGate(2, 3, 1, 2), 0, 1, 2)
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(Circuit)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	var want, got bytes.Buffer
+	c.Draw(&want, nil)
+	decoded.Draw(&got, nil)
+	if got.String() != want.String() {
+		t.Errorf("Bad decoded drawing:\n%s\nwant:\n%s", got.String(),
+			want.String())
+	}
+	for i, op := range decoded.Operations() {
+		gate, orig := op.Gate(), c.Operations()[i].Gate()
+		if (gate.permutation != nil) != (orig.permutation != nil) ||
+			(gate.diagonal != nil) != (orig.diagonal != nil) {
+			t.Errorf("Decoded gate %s changed kind", gate.Name())
+		}
+		if !gate.EqualsUpToPhase(orig) {
+			t.Errorf("Decoded gate %s changed matrix", gate.Name())
+		}
+	}
+	if !decoded.Operations()[2].Gate().phaseParams {
+		t.Error("Decoded phase shift lost its phase parameters")
+	}
+
+	bad := `{"version":1,"width":1,"clbits":[],"operations":[` +
+		`{"op":"gate","qubits":[0],"permutation":[0,0]}]}`
+	if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+		t.Error("Decoded a non-bijective permutation")
+	}
+	bad = `{"version":1,"width":1,"clbits":[],"operations":[` +
+		`{"op":"gate","qubits":[0],"diagonal":[[1,0],[2,0]]}]}`
+	if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+		t.Error("Decoded a non-unitary diagonal")
+	}
+}
+
+func TestCircuitReset(t *testing.T) {
+	c := NewCircuit(1, "m")
+	c.Apply(NewHadamardGate(1), 0)
+	c.Reset(0)
+	c.Measure(0, "m")
+	for i := 0; i < 10; i++ {
+		if creg := c.Run(NewQReg(1, 0)); creg.Get("m") != 0 {
+			t.Fatal("Measured 1 after a reset")
+		}
+	}
+}
+
+func TestCircuitBarrier(t *testing.T) {
+	c := NewCircuit(2)
+	c.Apply(NewHadamardGate(1), 0)
+	c.Barrier()
+	c.Apply(NewCNOTGate(), 0, 1)
+	if qubits := c.Operations()[1].Qubits(); len(qubits) != 2 {
+		t.Errorf("Bad barrier qubits %v, want [0 1]", qubits)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(Circuit)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	// The barrier must not change the unitary
+	want := NewCircuit(2)
+	want.Apply(NewHadamardGate(1), 0)
+	want.Apply(NewCNOTGate(), 0, 1)
+	if !decoded.ToGate().EqualsUpToPhase(want.ToGate()) {
+		t.Error("Barrier changed the circuit's unitary")
+	}
+
+	bad := `{"version":1,"width":1,"clbits":["m"],"operations":[` +
+		`{"op":"barrier","qubits":[0],` +
+		`"condition":{"clbits":["m"],"value":1}}]}`
+	if err := json.Unmarshal([]byte(bad), decoded); err == nil {
+		t.Error("Decoded a conditioned barrier")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Conditioned a barrier")
+		}
+	}()
+	c = NewCircuit(1, "m")
+	c.Barrier().CIf(1, "m")
+}
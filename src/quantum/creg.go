@@ -0,0 +1,90 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+)
+
+// Represents a register of named classical bits
+type ClassicalReg struct {
+	// The names of the bits, in the order they were added.
+	names []string
+
+	// The value (0 or 1) of each bit.
+	values map[string]int
+}
+
+// Constructor for a ClassicalReg with every bit set to 0
+func NewClassicalReg(names ...string) *ClassicalReg {
+	creg := &ClassicalReg{values: make(map[string]int)}
+	for _, name := range names {
+		creg.Add(name)
+	}
+	return creg
+}
+
+// Add a bit, set to 0, to the register
+func (creg *ClassicalReg) Add(name string) {
+	if creg.Has(name) {
+		panic(fmt.Sprintf("Classical bit %q is already defined", name))
+	}
+	creg.names = append(creg.names, name)
+	creg.values[name] = 0
+}
+
+// This tells us whether the register has a bit with the given name
+func (creg *ClassicalReg) Has(name string) bool {
+	_, ok := creg.values[name]
+	return ok
+}
+
+// Accessor for the names of the bits
+func (creg *ClassicalReg) Names() []string {
+	names := make([]string, len(creg.names))
+	copy(names, creg.names)
+	return names
+}
+
+// Get the value of a bit
+func (creg *ClassicalReg) Get(name string) int {
+	value, ok := creg.values[name]
+	if !ok {
+		panic(fmt.Sprintf("No classical bit named %q", name))
+	}
+	return value
+}
+
+// Set the value of a bit
+func (creg *ClassicalReg) Set(name string, value int) {
+	if !creg.Has(name) {
+		panic(fmt.Sprintf("No classical bit named %q", name))
+	}
+	if value < 0 || value > 1 {
+		panic(fmt.Sprintf("Value %d should be either 0 or 1", value))
+	}
+	creg.values[name] = value
+}
+
+// Read several bits as an integer, with names[i] as bit i
+func (creg *ClassicalReg) Value(names ...string) int {
+	value := 0
+	for i, name := range names {
+		value |= creg.Get(name) << uint(i)
+	}
+	return value
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Represents a (possibly mixed) state of a set of qubits as a density matrix
+type DensityMatrix struct {
+	// The width (number of qubits) of the state.
+	width int
+
+	// The matrix elements in row-major order.  There are
+	// math.Pow(4,width) of these.
+	elements []complex128
+}
+
+// Construct the density matrix |psi><psi| of a quantum register
+func NewDensityMatrix(qreg *QReg) *DensityMatrix {
+	dim := len(qreg.amplitudes)
+	rho := &DensityMatrix{qreg.width, make([]complex128, dim*dim)}
+	for row, a := range qreg.amplitudes {
+		for col, b := range qreg.amplitudes {
+			rho.elements[row*dim+col] = a * cmplx.Conj(b)
+		}
+	}
+	return rho
+}
+
+// Get the reduced density matrix of the given qubits by tracing out every
+// other qubit of the register.  Qubit qubits[i] becomes qubit i of the result.
+func (qreg *QReg) PartialTrace(qubits []int) *DensityMatrix {
+	qreg.checkQubits(qubits)
+	rest := qreg.otherQubits(qubits)
+	dim := 1 << uint(len(qubits))
+	rho := &DensityMatrix{len(qubits), make([]complex128, dim*dim)}
+	// Arrange the amplitudes as a matrix m[i][k], where i is the value of
+	// the kept qubits and k the value of the traced-out ones, so that the
+	// reduced state is m m^dagger.
+	m := make([][]complex128, dim)
+	for i := range m {
+		m[i] = make([]complex128, 1<<uint(len(rest)))
+	}
+	for state, amp := range qreg.amplitudes {
+		m[subValue(state, qubits)][subValue(state, rest)] = amp
+	}
+	for row := 0; row < dim; row++ {
+		for col := 0; col < dim; col++ {
+			sum := complex(0, 0)
+			for k, amp := range m[row] {
+				sum += amp * cmplx.Conj(m[col][k])
+			}
+			rho.elements[row*dim+col] = sum
+		}
+	}
+	return rho
+}
+
+// Accessor for the width of a DensityMatrix
+func (rho *DensityMatrix) Width() int {
+	return rho.width
+}
+
+func (rho *DensityMatrix) dim() int {
+	return 1 << uint(rho.width)
+}
+
+// Get a single element of the matrix
+func (rho *DensityMatrix) Get(row int, col int) complex128 {
+	return rho.elements[row*rho.dim()+col]
+}
+
+// Get the trace of the matrix (it should always be 1)
+func (rho *DensityMatrix) Trace() complex128 {
+	sum := complex(0, 0)
+	for i := 0; i < rho.dim(); i++ {
+		sum += rho.Get(i, i)
+	}
+	return sum
+}
+
+// Get Tr(rho^2), which is 1 for pure states and 1/2**width for the maximally
+// mixed state
+func (rho *DensityMatrix) Purity() float64 {
+	sum := float64(0.0)
+	for _, element := range rho.elements {
+		sum += real(element * cmplx.Conj(element))
+	}
+	return sum
+}
+
+// This tells us whether or not the state is pure
+func (rho *DensityMatrix) IsPure() bool {
+	return math.Abs(rho.Purity()-1) < .0000000001
+}
+
+// This tells us whether the given qubits are in a pure state that is not
+// entangled with the rest of the register
+func (qreg *QReg) IsProductState(qubits []int) bool {
+	return qreg.PartialTrace(qubits).IsPure()
+}
+
+// Extract the state of the given qubits as a register of their own.  This is
+// only possible when they are in a product state with the rest of the
+// register; otherwise nil and false are returned.  The extracted state is
+// determined up to a global phase.
+func (qreg *QReg) SubsystemState(qubits []int) (*QReg, bool) {
+	if !qreg.IsProductState(qubits) {
+		return nil, false
+	}
+	// When the state is |a>|b>, every nonzero slice of amplitudes with the
+	// other qubits fixed is proportional to |a>.  Take the largest one.
+	rest := qreg.otherQubits(qubits)
+	norms := make([]float64, 1<<uint(len(rest)))
+	for state, _ := range qreg.amplitudes {
+		norms[subValue(state, rest)] += qreg.StateProb(state)
+	}
+	best := 0
+	for k, norm := range norms {
+		if norm > norms[best] {
+			best = k
+		}
+	}
+	sub := &QReg{width: len(qubits),
+		amplitudes: make([]complex128, 1<<uint(len(qubits)))}
+	amp_factor := complex(1.0/math.Sqrt(norms[best]), 0)
+	for state, amp := range qreg.amplitudes {
+		if subValue(state, rest) == best {
+			sub.amplitudes[subValue(state, qubits)] = amp * amp_factor
+		}
+	}
+	return sub, true
+}
+
+func (rho *DensityMatrix) Print() {
+	printMatrix(rho.Get, rho.dim())
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestPartialTrace_Bell(t *testing.T) {
+	qreg := NewQReg(2, 0)
+	Hadamard(qreg, 0)
+	NewClassicalGate(func(x int) int {
+		return x ^ (x&1)<<1
+	}, 2).ApplyReg(qreg)
+	rho := qreg.PartialTrace([]int{1})
+	want := []complex128{.5, 0, 0, .5}
+	for i, element := range want {
+		if v := rho.Get(i/2, i%2); cmplx.Abs(v-element) > 1e-9 {
+			t.Errorf("Bad element %d, %d = %+f, want %+f",
+				i/2, i%2, v, element)
+		}
+	}
+	if purity := rho.Purity(); math.Abs(purity-.5) > 1e-9 {
+		t.Errorf("Bad purity of Bell state half = %f, want 0.5", purity)
+	}
+	if qreg.IsProductState([]int{0}) {
+		t.Error("Bell state reported as a product state")
+	}
+	if _, ok := qreg.SubsystemState([]int{0}); ok {
+		t.Error("Extracted a subsystem of a Bell state")
+	}
+}
+
+func TestPartialTrace_Product(t *testing.T) {
+	a := NewQReg(1, 0)
+	a.amplitudes[0] = complex(.6, 0)
+	a.amplitudes[1] = complex(0, .8)
+	b := NewQReg(2, 2)
+	HadamardRange(b, 0, 1)
+	qreg := b.Tensor(a)
+	rho := qreg.PartialTrace([]int{2})
+	if v := rho.Get(0, 1); cmplx.Abs(v-complex(0, -.48)) > 1e-9 {
+		t.Errorf("Bad off-diagonal element = %+f, want -0.48i", v)
+	}
+	if trace := rho.Trace(); cmplx.Abs(trace-1) > 1e-9 {
+		t.Errorf("Bad trace = %+f, want 1", trace)
+	}
+	if !qreg.IsProductState([]int{2}) {
+		t.Fatal("Product state not recognized")
+	}
+	sub, ok := qreg.SubsystemState([]int{2})
+	if !ok {
+		t.Fatal("Could not extract subsystem state")
+	}
+	for i, amp := range a.amplitudes {
+		if cmplx.Abs(sub.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				i, sub.amplitudes[i], amp)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// Circuit diagrams.  Operations are packed into columns of operations whose
+// wires do not overlap and are drawn from left to right, with one wire per
+// qubit followed by one wire per classical bit.
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options for drawing a circuit
+type DrawOptions struct {
+	// Use only ASCII characters rather than box-drawing characters.
+	ASCII bool
+
+	// The maximum length of a line of text, past which the circuit wraps
+	// onto further rows.  Zero means no limit.
+	Width int
+
+	// Labels for the qubit wires.  Qubit i is labeled "qi" by default.
+	Labels []string
+
+	// The maximum number of columns of operations per row, past which the
+	// circuit wraps onto further rows.  Zero means no limit.
+	Fold int
+}
+
+// Get the label of each wire: the qubits, then the classical bits
+func (c *Circuit) wireLabels(options *DrawOptions) []string {
+	labels := make([]string, 0, c.width+len(c.clbits))
+	for qubit := 0; qubit < c.width; qubit++ {
+		if options != nil && qubit < len(options.Labels) {
+			labels = append(labels, options.Labels[qubit])
+		} else {
+			labels = append(labels, fmt.Sprintf("q%d", qubit))
+		}
+	}
+	return append(labels, c.clbits...)
+}
+
+// Get the wire of a classical bit
+func (c *Circuit) clbitWire(name string) int {
+	for i, clbit := range c.clbits {
+		if clbit == name {
+			return c.width + i
+		}
+	}
+	panic(fmt.Sprintf("No classical bit named %q", name))
+}
+
+// Get the top and bottom wires an operation spans, along with its bottom
+// qubit wire.  Below that, it reaches down to classical bits.
+func (c *Circuit) opSpan(op *Operation) (int, int, int) {
+	top, bottom := op.qubits[0], op.qubits[0]
+	for _, qubit := range op.qubits {
+		if qubit < top {
+			top = qubit
+		}
+		if qubit > bottom {
+			bottom = qubit
+		}
+	}
+	quantum_bottom := bottom
+	clbits := []string{}
+	if op.kind == measureOp {
+		clbits = append(clbits, op.clbit)
+	}
+	if op.condition != nil {
+		clbits = append(clbits, op.condition.clbits...)
+	}
+	for _, clbit := range clbits {
+		if wire := c.clbitWire(clbit); wire > bottom {
+			bottom = wire
+		}
+	}
+	return top, bottom, quantum_bottom
+}
+
+// Pack the operations into columns.  Each operation goes in the column after
+// the last one holding an operation on any of the wires it spans, so the
+// operations on every wire keep their order.
+func (c *Circuit) columns() [][]*Operation {
+	next := make([]int, c.width+len(c.clbits))
+	columns := [][]*Operation{}
+	for _, op := range c.ops {
+		top, bottom, _ := c.opSpan(op)
+		col := 0
+		for wire := top; wire <= bottom; wire++ {
+			if next[wire] > col {
+				col = next[wire]
+			}
+		}
+		if col == len(columns) {
+			columns = append(columns, nil)
+		}
+		columns[col] = append(columns[col], op)
+		for wire := top; wire <= bottom; wire++ {
+			next[wire] = col + 1
+		}
+	}
+	return columns
+}
+
+// Get the label of a gate along with its parameters
+func gateLabel(gate *Gate) string {
+	if len(gate.params) == 0 {
+		return gate.Name()
+	}
+	params := make([]string, len(gate.params))
+	for i, param := range gate.params {
+		params[i] = fmt.Sprintf("%.4g", param)
+	}
+	return fmt.Sprintf("%s(%s)", gate.Name(), strings.Join(params, ","))
+}
+
+// Get the shape of a gate operation's target with the given index: "control",
+// "target", "swap" or "box"
+func targetShape(op *Operation, i int) string {
+	controls := op.gate.controls
+	targets := len(op.qubits) - controls
+	switch {
+	case i < controls:
+		return "control"
+	case op.gate.Name() == "X" && controls > 0 && targets == 1:
+		return "target"
+	case op.gate.Name() == "SWAP" && targets == 2:
+		return "swap"
+	}
+	return "box"
+}
+
+// The characters a text diagram is drawn with
+type textSymbols struct {
+	wire, clWire               string
+	vertical, clVertical       string
+	cross, clCross, clClCross  string
+	control, target, swap      string
+	boxLeft, boxRight          string
+	measured, reset            string
+	conditionOne, conditionOff string
+	barrier                    string
+}
+
+var unicodeSymbols = &textSymbols{
+	wire: "─", clWire: "═",
+	vertical: "│", clVertical: "║",
+	cross: "┼", clCross: "╫", clClCross: "╬",
+	control: "●", target: "⊕", swap: "×",
+	boxLeft: "┤", boxRight: "├",
+	measured: "╩", reset: "|0⟩",
+	conditionOne: "●", conditionOff: "○",
+	barrier: "░",
+}
+
+var asciiSymbols = &textSymbols{
+	wire: "-", clWire: "=",
+	vertical: "|", clVertical: "|",
+	cross: "+", clCross: "|", clClCross: "|",
+	control: "*", target: "(+)", swap: "x",
+	boxLeft: "[", boxRight: "]",
+	measured: "v", reset: "|0>",
+	conditionOne: "1", conditionOff: "0",
+	barrier: "#",
+}
+
+// One column of a text diagram: a symbol on each wire and a connector
+// between each pair of neighboring wires, either of which may be empty
+type textColumn struct {
+	cells      []string
+	connectors []string
+	width      int
+}
+
+// Get the text of one column of a diagram
+func (c *Circuit) textColumn(ops []*Operation, s *textSymbols) *textColumn {
+	wires := c.width + len(c.clbits)
+	col := &textColumn{make([]string, wires), make([]string, wires), 1}
+	for _, op := range ops {
+		if op.kind == barrierOp {
+			in := make(map[int]bool)
+			for _, qubit := range op.qubits {
+				col.cells[qubit] = s.barrier
+				in[qubit] = true
+			}
+			for _, qubit := range op.qubits {
+				if in[qubit+1] {
+					col.connectors[qubit] = s.barrier
+				}
+			}
+			continue
+		}
+		switch op.kind {
+		case measureOp:
+			col.cells[op.qubits[0]] = s.boxLeft + "M" + s.boxRight
+			col.cells[c.clbitWire(op.clbit)] = s.measured
+		case resetOp:
+			col.cells[op.qubits[0]] = s.reset
+		case gateOp:
+			controls := op.gate.controls
+			targets := len(op.qubits) - controls
+			label := gateLabel(op.gate)
+			for i, qubit := range op.qubits {
+				cell := ""
+				switch targetShape(op, i) {
+				case "control":
+					cell = s.control
+				case "target":
+					cell = s.target
+				case "swap":
+					cell = s.swap
+				default:
+					cell = s.boxLeft + label + s.boxRight
+					if targets > 1 {
+						cell = fmt.Sprintf("%s%s:%d%s", s.boxLeft,
+							label, i-controls, s.boxRight)
+					}
+				}
+				col.cells[qubit] = cell
+			}
+		}
+		if op.condition != nil {
+			for i, clbit := range op.condition.clbits {
+				cell := s.conditionOff
+				if op.condition.value>>uint(i)&1 == 1 {
+					cell = s.conditionOne
+				}
+				col.cells[c.clbitWire(clbit)] = cell
+			}
+		}
+		// Connect the wires the operation spans
+		top, bottom, quantum_bottom := c.opSpan(op)
+		for wire := top; wire <= bottom; wire++ {
+			if col.cells[wire] == "" {
+				switch {
+				case wire >= c.width:
+					col.cells[wire] = s.clClCross
+				case wire < quantum_bottom:
+					col.cells[wire] = s.cross
+				default:
+					col.cells[wire] = s.clCross
+				}
+			}
+			if wire < bottom {
+				col.connectors[wire] = s.vertical
+				if wire >= quantum_bottom {
+					col.connectors[wire] = s.clVertical
+				}
+			}
+		}
+	}
+	for _, cell := range col.cells {
+		if n := utf8.RuneCountInString(cell); n > col.width {
+			col.width = n
+		}
+	}
+	return col
+}
+
+// Center text in a field of the given width, padding it with fill
+func center(text string, width int, fill string) string {
+	pad := width - utf8.RuneCountInString(text)
+	return strings.Repeat(fill, pad/2) + text + strings.Repeat(fill, pad-pad/2)
+}
+
+// Draw the circuit as text, with a line for each wire and a line between
+// each pair of wires for the connections between them
+func (c *Circuit) Draw(w io.Writer, options *DrawOptions) error {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+	s := unicodeSymbols
+	if options.ASCII {
+		s = asciiSymbols
+	}
+	labels := c.wireLabels(options)
+	label_width := 0
+	for _, label := range labels {
+		if n := utf8.RuneCountInString(label); n > label_width {
+			label_width = n
+		}
+	}
+	prefix_width := label_width + 2
+	columns := []*textColumn{}
+	for _, ops := range c.columns() {
+		columns = append(columns, c.textColumn(ops, s))
+	}
+	// Break the columns into rows that fit in the width
+	rows := [][]*textColumn{{}}
+	line_width := prefix_width + 1
+	for _, col := range columns {
+		row := rows[len(rows)-1]
+		if options.Width > 0 && len(row) > 0 &&
+			line_width+col.width+1 > options.Width ||
+			options.Fold > 0 && len(row) == options.Fold {
+			rows = append(rows, nil)
+			line_width = prefix_width + 1
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], col)
+		line_width += col.width + 1
+	}
+	for r, row := range rows {
+		if r > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		for wire, label := range labels {
+			fill := s.wire
+			if wire >= c.width {
+				fill = s.clWire
+			}
+			line := label + ":" + strings.Repeat(" ",
+				prefix_width-1-utf8.RuneCountInString(label))
+			for _, col := range row {
+				line += fill + center(col.cells[wire], col.width, fill)
+			}
+			line += fill + "\n"
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+			if wire == len(labels)-1 {
+				break
+			}
+			line = strings.Repeat(" ", prefix_width)
+			for _, col := range row {
+				line += " " + center(col.connectors[wire], col.width, " ")
+			}
+			line = strings.TrimRight(line, " ") + "\n"
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Print the circuit as text
+func (c *Circuit) Print() {
+	c.Draw(os.Stdout, nil)
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Helper function for testing. Makes the teleportation circuit with its
+// gates named as the diagram tests expect.
+func newDrawTestCircuit() *Circuit {
+	c := NewCircuit(3, "m0", "m1")
+	c.Apply(NewHadamardGate(1), 1)
+	c.Apply(NewCNOTGate(), 1, 2)
+	c.Apply(NewCNOTGate(), 0, 1)
+	c.Apply(NewHadamardGate(1), 0)
+	c.Measure(0, "m0")
+	c.Measure(1, "m1")
+	c.Apply(NewPauliXGate(), 2).CIf(1, "m1")
+	c.Apply(NewPauliZGate(), 2).CIf(1, "m0")
+	return c
+}
+
+func verifyDrawing(t *testing.T, c *Circuit, options *DrawOptions, want string) {
+	var buf bytes.Buffer
+	if err := c.Draw(&buf, options); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("Bad drawing:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	verifyDrawing(t, newDrawTestCircuit(), nil, `q0: ───────●─┤H├─┤M├─────────────
+           │      ║
+q1: ─┤H├─●─⊕──────╫──┤M├─────────
+         │        ║   ║
+q2: ─────⊕────────╫───╫──┤X├─┤Z├─
+                  ║   ║   ║   ║
+m0: ══════════════╩═══╬═══╬═══●══
+                      ║   ║
+m1: ══════════════════╩═══●══════
+`)
+}
+
+func TestDraw_ASCII(t *testing.T) {
+	options := &DrawOptions{
+		ASCII:  true,
+		Width:  30,
+		Labels: []string{"msg", "alice", "bob"},
+	}
+	verifyDrawing(t, newDrawTestCircuit(), options, `msg:   ----------*--[H]-[M]-
+                 |       |
+alice: -[H]--*--(+)------|--
+             |           |
+bob:   -----(+)----------|--
+                         |
+m0:    ==================v==
+
+m1:    =====================
+
+msg:   -------------
+
+alice: -[M]---------
+         |
+bob:   --|--[X]-[Z]-
+         |   |   |
+m0:    ==|===|===1==
+         |   |
+m1:    ==v===1======
+`)
+}
+
+func TestDraw_Gates(t *testing.T) {
+	c := NewCircuit(4)
+	c.Apply(NewControlledGate(NewPhaseShiftGate(.5), 1), 3, 0)
+	c.Apply(NewQFTGate(2), 1, 3)
+	c.Apply(NewSwapGate(), 0, 2)
+	c.Reset(3)
+	verifyDrawing(t, c, nil, `q0: ─┤P(0.5)├──────────×──
+        │              │
+q1: ────┼─────┤QFT:0├──┼──
+        │        │     │
+q2: ────┼────────┼─────×──
+        │        │
+q3: ────●─────┤QFT:1├─|0⟩─
+`)
+}
+
+func TestDraw_Fold(t *testing.T) {
+	c := NewCircuit(1)
+	c.Apply(NewHadamardGate(1), 0)
+	c.Barrier()
+	c.Apply(NewHadamardGate(1), 0)
+	verifyDrawing(t, c, &DrawOptions{Fold: 2}, "q0: ─┤H├─░─\n\nq0: ─┤H├─\n")
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// QRegs are serialized along with a format version and their width.  In both
+// formats, amplitude i is the amplitude of the basis state whose binary
+// representation has qubit j as bit j, so qubit 0 is the least significant
+// bit of the index.  Named sub-registers are not serialized.
+//
+// The binary format is the magic string "QREG", a version byte, an endianness
+// byte (0 for little-endian, 1 for big-endian), the width as a uint32, and
+// then the real and imaginary part of each amplitude as float64s.  Multi-byte
+// values use the endianness given in the header.
+//
+// The JSON format is an object such as
+//   {"version":1,"width":1,"qubit_order":"little","amplitudes":[[1,0],[0,0]]}
+// where "qubit_order" is the order of the qubits in the amplitude indices
+// ("little" meaning qubit 0 is the least significant bit), not a byte order.
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+const (
+	qregMagic         = "QREG"
+	qregFormatVersion = 1
+	qregLittleEndian  = 0
+	qregBigEndian     = 1
+	qregMaxWidth      = 30
+	qregNormTolerance = .000000001
+)
+
+// Check that a decoded state has the right number of amplitudes and is
+// normalized
+func checkDecodedState(width int, amplitudes []complex128) error {
+	if width < 0 || width > qregMaxWidth {
+		return fmt.Errorf("quantum: invalid width %d", width)
+	}
+	if len(amplitudes) != 1<<uint(width) {
+		return fmt.Errorf("quantum: %d amplitudes for width %d",
+			len(amplitudes), width)
+	}
+	norm := float64(0.0)
+	for i, amp := range amplitudes {
+		if cmplx.IsNaN(amp) || cmplx.IsInf(amp) {
+			return fmt.Errorf("quantum: amplitude %d is not finite",
+				i)
+		}
+		norm += real(amp)*real(amp) + imag(amp)*imag(amp)
+	}
+	if math.Abs(norm-1) > qregNormTolerance {
+		return fmt.Errorf("quantum: state is not normalized "+
+			"(squared norm %v)", norm)
+	}
+	return nil
+}
+
+// Encode the register in the binary format
+func (qreg *QReg) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(qregMagic)
+	buf.WriteByte(qregFormatVersion)
+	buf.WriteByte(qregLittleEndian)
+	binary.Write(&buf, binary.LittleEndian, uint32(qreg.width))
+	for _, amp := range qreg.amplitudes {
+		binary.Write(&buf, binary.LittleEndian,
+			[2]float64{real(amp), imag(amp)})
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode a register in the binary format, replacing the register's state
+func (qreg *QReg) UnmarshalBinary(data []byte) error {
+	header_size := len(qregMagic) + 6
+	if len(data) < header_size || string(data[:len(qregMagic)]) != qregMagic {
+		return errors.New("quantum: not an encoded QReg")
+	}
+	data = data[len(qregMagic):]
+	if data[0] != qregFormatVersion {
+		return fmt.Errorf("quantum: unsupported QReg format version %d",
+			data[0])
+	}
+	var order binary.ByteOrder
+	switch data[1] {
+	case qregLittleEndian:
+		order = binary.LittleEndian
+	case qregBigEndian:
+		order = binary.BigEndian
+	default:
+		return fmt.Errorf("quantum: unknown endianness %d", data[1])
+	}
+	width := int(order.Uint32(data[2:6]))
+	if width > qregMaxWidth {
+		return fmt.Errorf("quantum: invalid width %d", width)
+	}
+	data = data[6:]
+	if len(data) != 16<<uint(width) {
+		return fmt.Errorf("quantum: %d bytes of amplitudes for width %d",
+			len(data), width)
+	}
+	amplitudes := make([]complex128, 1<<uint(width))
+	for i := range amplitudes {
+		re := math.Float64frombits(order.Uint64(data[16*i:]))
+		im := math.Float64frombits(order.Uint64(data[16*i+8:]))
+		amplitudes[i] = complex(re, im)
+	}
+	if err := checkDecodedState(width, amplitudes); err != nil {
+		return err
+	}
+	qreg.width = width
+	qreg.amplitudes = amplitudes
+	qreg.subs = nil
+	return nil
+}
+
+type qregJSON struct {
+	Version    int          `json:"version"`
+	Width      int          `json:"width"`
+	QubitOrder string       `json:"qubit_order"`
+	Amplitudes [][2]float64 `json:"amplitudes"`
+}
+
+// Convert amplitudes to [real, imaginary] pairs for JSON
+func complexPairs(amplitudes []complex128) [][2]float64 {
+	pairs := make([][2]float64, len(amplitudes))
+	for i, amp := range amplitudes {
+		pairs[i] = [2]float64{real(amp), imag(amp)}
+	}
+	return pairs
+}
+
+// Convert [real, imaginary] pairs from JSON back to amplitudes
+func pairsComplex(pairs [][2]float64) []complex128 {
+	amplitudes := make([]complex128, len(pairs))
+	for i, pair := range pairs {
+		amplitudes[i] = complex(pair[0], pair[1])
+	}
+	return amplitudes
+}
+
+// Encode the register in the JSON format
+func (qreg *QReg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(qregJSON{qregFormatVersion, qreg.width, "little",
+		complexPairs(qreg.amplitudes)})
+}
+
+// Decode a register in the JSON format, replacing the register's state
+func (qreg *QReg) UnmarshalJSON(data []byte) error {
+	var decoded qregJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version != qregFormatVersion {
+		return fmt.Errorf("quantum: unsupported QReg format version %d",
+			decoded.Version)
+	}
+	if decoded.QubitOrder != "little" {
+		return fmt.Errorf("quantum: unsupported qubit order %q",
+			decoded.QubitOrder)
+	}
+	amplitudes := pairsComplex(decoded.Amplitudes)
+	if err := checkDecodedState(decoded.Width, amplitudes); err != nil {
+		return err
+	}
+	qreg.width = decoded.Width
+	qreg.amplitudes = amplitudes
+	qreg.subs = nil
+	return nil
+}
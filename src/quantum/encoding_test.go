@@ -0,0 +1,127 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Helper function for testing. Returns a register in a state with distinct
+// complex amplitudes.
+func newTestQReg() *QReg {
+	qreg := NewQReg(2, 0)
+	qreg.amplitudes = []complex128{
+		complex(.5, 0), complex(0, .5), complex(-.5, 0), complex(0, -.5),
+	}
+	qreg.amplitudes[1] *= cmplx.Exp(complex(0, .3))
+	return qreg
+}
+
+func verifySameState(t *testing.T, got *QReg, want *QReg) {
+	if got.Width() != want.Width() {
+		t.Fatalf("Bad width = %d, want %d", got.Width(), want.Width())
+	}
+	for i, amp := range want.amplitudes {
+		if got.amplitudes[i] != amp {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				i, got.amplitudes[i], amp)
+		}
+	}
+}
+
+func TestQRegBinary(t *testing.T) {
+	qreg := newTestQReg()
+	data, err := qreg.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 10+4*16 {
+		t.Errorf("Bad encoded length %d, want %d", len(data), 10+4*16)
+	}
+	decoded := NewQReg(1)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	verifySameState(t, decoded, qreg)
+}
+
+func TestQRegBinary_BigEndian(t *testing.T) {
+	qreg := NewQReg(1, 1)
+	data := []byte("QREG\x01\x01")
+	data = binary.BigEndian.AppendUint32(data, 1)
+	for _, v := range []float64{0, 0, 1, 0} {
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(v))
+	}
+	decoded := NewQReg(3)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	verifySameState(t, decoded, qreg)
+}
+
+func TestQRegBinary_Invalid(t *testing.T) {
+	data, _ := NewQReg(1, 0).MarshalBinary()
+	bad_version := append([]byte{}, data...)
+	bad_version[4] = 2
+	truncated := data[:len(data)-1]
+	unnormalized := append([]byte{}, data...)
+	binary.LittleEndian.PutUint64(unnormalized[10:], math.Float64bits(2))
+	nan := append([]byte{}, data...)
+	binary.LittleEndian.PutUint64(nan[26:], math.Float64bits(math.NaN()))
+	inf := append([]byte{}, data...)
+	binary.LittleEndian.PutUint64(inf[34:], math.Float64bits(math.Inf(-1)))
+	for name, bad := range map[string][]byte{
+		"version":      bad_version,
+		"truncated":    truncated,
+		"unnormalized": unnormalized,
+		"NaN":          nan,
+		"Inf":          inf,
+		"magic":        []byte("QRG"),
+	} {
+		if err := NewQReg(1).UnmarshalBinary(bad); err == nil {
+			t.Errorf("Decoded QReg with bad %s", name)
+		}
+	}
+}
+
+func TestQRegJSON(t *testing.T) {
+	qreg := newTestQReg()
+	data, err := json.Marshal(qreg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(QReg)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	verifySameState(t, decoded, qreg)
+
+	unnormalized := `{"version":1,"width":1,"qubit_order":"little",` +
+		`"amplitudes":[[1,0],[1,0]]}`
+	if err := json.Unmarshal([]byte(unnormalized), decoded); err == nil {
+		t.Error("Decoded unnormalized QReg")
+	}
+	short := `{"version":1,"width":2,"qubit_order":"little",` +
+		`"amplitudes":[[1,0],[0,0]]}`
+	if err := json.Unmarshal([]byte(short), decoded); err == nil {
+		t.Error("Decoded QReg with too few amplitudes")
+	}
+}
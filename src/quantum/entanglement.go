@@ -0,0 +1,190 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Get the eigenvalues of the matrix in decreasing order
+func (rho *DensityMatrix) Eigenvalues() []float64 {
+	vals, _ := hermitianEigen(rho.elements, rho.dim())
+	return vals
+}
+
+// Get the von Neumann entropy -Tr(rho log2 rho), in bits
+func (rho *DensityMatrix) VonNeumannEntropy() float64 {
+	entropy := float64(0.0)
+	for _, val := range rho.Eigenvalues() {
+		if val > eigenTolerance {
+			entropy -= val * math.Log2(val)
+		}
+	}
+	return entropy
+}
+
+// Get the Renyi entropy log2(Tr(rho**alpha)) / (1 - alpha), in bits.  An
+// alpha of 1 gives the von Neumann entropy and an alpha of math.Inf(1) gives
+// the min-entropy.
+func (rho *DensityMatrix) RenyiEntropy(alpha float64) float64 {
+	if alpha < 0 {
+		panic(fmt.Sprintf("Renyi entropy of order %f is undefined", alpha))
+	}
+	if alpha == 1 {
+		return rho.VonNeumannEntropy()
+	}
+	vals := rho.Eigenvalues()
+	if math.IsInf(alpha, 1) {
+		return -math.Log2(vals[0])
+	}
+	sum := float64(0.0)
+	for _, val := range vals {
+		if val > eigenTolerance {
+			sum += math.Pow(val, alpha)
+		}
+	}
+	return math.Log2(sum) / (1 - alpha)
+}
+
+// Get the von Neumann entropy of the given qubits, which measures their
+// entanglement with the rest of the register
+func (qreg *QReg) VonNeumannEntropy(qubits []int) float64 {
+	return qreg.PartialTrace(qubits).VonNeumannEntropy()
+}
+
+// Get the Renyi entropy of the given qubits
+func (qreg *QReg) RenyiEntropy(qubits []int, alpha float64) float64 {
+	return qreg.PartialTrace(qubits).RenyiEntropy(alpha)
+}
+
+// Write the state as sum_j c_j |a_j>|b_j>, where the |a_j> are orthonormal
+// states of the given qubits and the |b_j> are orthonormal states of the
+// remaining qubits, taken in increasing order.  Only the nonzero coefficients
+// are returned, in decreasing order, along with the matching states.
+func (qreg *QReg) SchmidtDecomposition(qubits []int) ([]float64, []*QReg, []*QReg) {
+	qreg.checkQubits(qubits)
+	rest := qreg.otherQubits(qubits)
+	n := 1 << uint(len(qubits))
+	p := 1 << uint(len(rest))
+	m := make([]complex128, n*p)
+	for state, amp := range qreg.amplitudes {
+		m[subValue(state, qubits)*p+subValue(state, rest)] = amp
+	}
+	// m = u diag(s) v^dagger, so |a_j> is column j of u and |b_j> is the
+	// conjugate of column j of v.
+	s, u, v := svd(m, n, p)
+	k := len(s)
+	coefficients := make([]float64, 0, k)
+	a_states := make([]*QReg, 0, k)
+	b_states := make([]*QReg, 0, k)
+	for j := 0; j < k; j++ {
+		if s[j] < .0000000001 {
+			break
+		}
+		a := &QReg{width: len(qubits), amplitudes: make([]complex128, n)}
+		for i := 0; i < n; i++ {
+			a.amplitudes[i] = u[i*k+j]
+		}
+		b := &QReg{width: len(rest), amplitudes: make([]complex128, p)}
+		for i := 0; i < p; i++ {
+			b.amplitudes[i] = cmplx.Conj(v[i*k+j])
+		}
+		coefficients = append(coefficients, s[j])
+		a_states = append(a_states, a)
+		b_states = append(b_states, b)
+	}
+	return coefficients, a_states, b_states
+}
+
+// Get the Wootters concurrence of a two-qubit state
+func (rho *DensityMatrix) Concurrence() float64 {
+	if rho.width != 2 {
+		panic(fmt.Sprintf("Concurrence of a %d-qubit state is undefined",
+			rho.width))
+	}
+	// rho_tilde = (Y x Y) rho^* (Y x Y), where Y x Y is antidiagonal with
+	// entries -1, 1, 1, -1.
+	yy := []complex128{-1, 1, 1, -1}
+	tilde := make([]complex128, 16)
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			tilde[row*4+col] = yy[row] * yy[col] *
+				cmplx.Conj(rho.Get(3-row, 3-col))
+		}
+	}
+	// The square roots of the eigenvalues of rho rho_tilde are those of
+	// the Hermitian matrix sqrt(rho) rho_tilde sqrt(rho).
+	sqrt := hermitianFunc(rho.elements, 4, func(x float64) float64 {
+		return math.Sqrt(math.Max(x, 0))
+	})
+	r := matMul(matMul(sqrt, tilde, 4, 4, 4), sqrt, 4, 4, 4)
+	vals, _ := hermitianEigen(r, 4)
+	lambdas := make([]float64, 4)
+	for i, val := range vals {
+		lambdas[i] = math.Sqrt(math.Max(val, 0))
+	}
+	return math.Max(0, lambdas[0]-lambdas[1]-lambdas[2]-lambdas[3])
+}
+
+// Get the concurrence between two qubits of the register
+func (qreg *QReg) Concurrence(a int, b int) float64 {
+	return qreg.PartialTrace([]int{a, b}).Concurrence()
+}
+
+// Get the partial transpose of the matrix with respect to the given qubits
+func (rho *DensityMatrix) PartialTranspose(qubits []int) *DensityMatrix {
+	mask := 0
+	for _, qubit := range qubits {
+		if qubit < 0 || qubit >= rho.width {
+			panic(fmt.Sprintf("%d is not a valid qubit", qubit))
+		}
+		mask |= 1 << uint(qubit)
+	}
+	dim := rho.dim()
+	result := &DensityMatrix{rho.width, make([]complex128, dim*dim)}
+	for row := 0; row < dim; row++ {
+		for col := 0; col < dim; col++ {
+			// Exchange the row and column bits of the given qubits
+			t_row := row&^mask | col&mask
+			t_col := col&^mask | row&mask
+			result.elements[row*dim+col] = rho.Get(t_row, t_col)
+		}
+	}
+	return result
+}
+
+// Get the negativity of the state across the bipartition between the given
+// qubits and the rest, which is the sum of the magnitudes of the negative
+// eigenvalues of the partial transpose
+func (rho *DensityMatrix) Negativity(qubits []int) float64 {
+	negativity := float64(0.0)
+	for _, val := range rho.PartialTranspose(qubits).Eigenvalues() {
+		if val < 0 {
+			negativity -= val
+		}
+	}
+	return negativity
+}
+
+// Get the negativity of the register across the bipartition between the
+// given qubits and the rest
+func (qreg *QReg) Negativity(qubits []int) float64 {
+	qreg.checkQubits(qubits)
+	return NewDensityMatrix(qreg).Negativity(qubits)
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Helper function for testing. Prepares (|00> + |11>)/sqrt(2) on qubits a and
+// b of a register of the given width.
+func newBellQReg(width int, a int, b int) *QReg {
+	qreg := NewQReg(width, 0)
+	qreg.amplitudes[0] = complex(1/math.Sqrt2, 0)
+	qreg.amplitudes[1<<uint(a)|1<<uint(b)] = complex(1/math.Sqrt2, 0)
+	return qreg
+}
+
+func TestEntropy_Bell(t *testing.T) {
+	qreg := newBellQReg(3, 0, 2)
+	if s := qreg.VonNeumannEntropy([]int{0}); math.Abs(s-1) > 1e-9 {
+		t.Errorf("Bad entropy of Bell state half = %f, want 1", s)
+	}
+	if s := qreg.VonNeumannEntropy([]int{1}); math.Abs(s) > 1e-9 {
+		t.Errorf("Bad entropy of unentangled qubit = %f, want 0", s)
+	}
+	for _, alpha := range []float64{0, .5, 2, math.Inf(1)} {
+		s := qreg.RenyiEntropy([]int{0, 1}, alpha)
+		if math.Abs(s-1) > 1e-9 {
+			t.Errorf("Bad Renyi entropy of order %f = %f, want 1",
+				alpha, s)
+		}
+	}
+}
+
+func TestSchmidtDecomposition(t *testing.T) {
+	qreg := NewQReg(2, 0)
+	qreg.amplitudes[0] = complex(math.Sqrt(.8), 0)
+	qreg.amplitudes[3] = complex(0, math.Sqrt(.2))
+	coefficients, a, b := qreg.SchmidtDecomposition([]int{1})
+	if len(coefficients) != 2 {
+		t.Fatalf("Bad number of Schmidt coefficients %d, want 2",
+			len(coefficients))
+	}
+	if math.Abs(coefficients[0]-math.Sqrt(.8)) > 1e-9 ||
+		math.Abs(coefficients[1]-math.Sqrt(.2)) > 1e-9 {
+		t.Errorf("Bad Schmidt coefficients %v", coefficients)
+	}
+	// Rebuild the state from the decomposition
+	for state, amp := range qreg.amplitudes {
+		sum := complex(0, 0)
+		for j, c := range coefficients {
+			sum += complex(c, 0) * a[j].amplitudes[state>>1] *
+				b[j].amplitudes[state&1]
+		}
+		if cmplx.Abs(sum-amp) > 1e-9 {
+			t.Errorf("Bad rebuilt amplitude for state %d = %+f, "+
+				"want %+f", state, sum, amp)
+		}
+	}
+}
+
+func TestConcurrence(t *testing.T) {
+	qreg := newBellQReg(3, 1, 2)
+	if c := qreg.Concurrence(1, 2); math.Abs(c-1) > 1e-9 {
+		t.Errorf("Bad concurrence of Bell state = %f, want 1", c)
+	}
+	if c := qreg.Concurrence(0, 1); math.Abs(c) > 1e-9 {
+		t.Errorf("Bad concurrence of unentangled pair = %f, want 0", c)
+	}
+}
+
+func TestNegativity(t *testing.T) {
+	qreg := newBellQReg(2, 0, 1)
+	if n := qreg.Negativity([]int{0}); math.Abs(n-.5) > 1e-9 {
+		t.Errorf("Bad negativity of Bell state = %f, want 0.5", n)
+	}
+	qreg = NewQReg(2, 0)
+	HadamardReg(qreg)
+	if n := qreg.Negativity([]int{0}); math.Abs(n) > 1e-9 {
+		t.Errorf("Bad negativity of product state = %f, want 0", n)
+	}
+}
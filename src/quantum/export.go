@@ -0,0 +1,431 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// Export of circuit diagrams as quantikz LaTeX source and as SVG images.  Both
+// lay out the operations in the same columns as Circuit.Draw.
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Break the columns of a diagram into rows of at most fold columns
+func foldColumns(columns [][]*Operation, fold int) [][][]*Operation {
+	if fold <= 0 || len(columns) <= fold {
+		return [][][]*Operation{columns}
+	}
+	rows := [][][]*Operation{}
+	for start := 0; start < len(columns); start += fold {
+		end := start + fold
+		if end > len(columns) {
+			end = len(columns)
+		}
+		rows = append(rows, columns[start:end])
+	}
+	return rows
+}
+
+// This tells us whether the targets of a gate operation, after its controls,
+// are consecutive qubits in increasing order, so that they can be drawn as a
+// single box
+func isBlock(op *Operation) bool {
+	targets := op.qubits[op.gate.controls:]
+	if len(targets) < 2 {
+		return false
+	}
+	for i, qubit := range targets {
+		if qubit != targets[0]+i {
+			return false
+		}
+	}
+	return true
+}
+
+// LaTeX
+
+// Escape text for LaTeX text mode
+func escapeLaTeX(text string) string {
+	return strings.NewReplacer(`\`, `\textbackslash{}`, "{", `\{`,
+		"}", `\}`, "_", `\_`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`,
+		"^", `\^{}`, "~", `\~{}`).Replace(text)
+}
+
+// Escape text for LaTeX math mode, where the text-mode accents are not
+// allowed
+func escapeLaTeXMath(text string) string {
+	return strings.NewReplacer(`\`, `\backslash{}`, "{", `\{`, "}", `\}`,
+		"_", `\_`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`,
+		"^", `\hat{}`, "~", `\sim{}`).Replace(text)
+}
+
+// Get the math-mode label of a gate, with powers and adjoints as
+// superscripts and parameters in parentheses
+func latexGateLabel(gate *Gate) string {
+	name := gate.Name()
+	sup := ""
+	if i := strings.Index(name, "^"); i >= 0 {
+		name, sup = name[:i], name[i+1:]
+	}
+	if strings.HasSuffix(name, "†") {
+		name = strings.TrimSuffix(name, "†")
+		sup = "†" + sup
+	}
+	sup = strings.TrimSpace(strings.Replace(escapeLaTeXMath(sup), "†",
+		`\dagger `, -1))
+	label := escapeLaTeXMath(name)
+	if utf8.RuneCountInString(name) > 1 {
+		label = `\mathrm{` + label + "}"
+	}
+	if sup != "" {
+		label += "^{" + sup + "}"
+	}
+	if len(gate.params) > 0 {
+		label += strings.TrimPrefix(gateLabel(gate), gate.Name())
+	}
+	return label
+}
+
+// Get the quantikz cells of one column, leaving empty cells as plain wires
+func (c *Circuit) quantikzColumn(ops []*Operation) []string {
+	cells := make([]string, c.width+len(c.clbits))
+	for _, op := range ops {
+		top, bottom, quantum_bottom := c.opSpan(op)
+		switch op.kind {
+		case barrierOp:
+			// quantikz slices cross every wire, so barriers on only
+			// some of the qubits are left out
+			if len(op.qubits) == c.width {
+				cells[top] = `\qw \slice{}`
+			}
+			continue
+		case measureOp:
+			cells[op.qubits[0]] = `\meter{}`
+		case resetOp:
+			cells[op.qubits[0]] = `\gate{\ket{0}}`
+		case gateOp:
+			label := latexGateLabel(op.gate)
+			controls := op.gate.controls
+			for i, qubit := range op.qubits {
+				switch targetShape(op, i) {
+				case "control":
+					cells[qubit] = `\control{}`
+				case "target":
+					cells[qubit] = `\targ{}`
+				case "swap":
+					cells[qubit] = `\targX{}`
+				default:
+					if !isBlock(op) {
+						cells[qubit] = fmt.Sprintf(`\gate{%s_{%d}}`,
+							label, i-controls)
+						if len(op.qubits)-controls == 1 {
+							cells[qubit] = `\gate{` + label + "}"
+						}
+					} else if i == controls {
+						cells[qubit] = fmt.Sprintf(`\gate[%d]{%s}`,
+							len(op.qubits)-controls, label)
+					}
+				}
+			}
+		}
+		if op.condition != nil {
+			for i, clbit := range op.condition.clbits {
+				cell := `\ocontrol{}`
+				if op.condition.value>>uint(i)&1 == 1 {
+					cell = `\control{}`
+				}
+				cells[c.clbitWire(clbit)] = cell
+			}
+		}
+		// Draw a quantum wire from the top qubit to the bottom one and a
+		// classical wire on to the bottom classical bit
+		if quantum_bottom > top {
+			if cells[top] == `\control{}` {
+				cells[top] = fmt.Sprintf(`\ctrl{%d}`, quantum_bottom-top)
+			} else {
+				cells[top] += fmt.Sprintf(` \vqw{%d}`, quantum_bottom-top)
+			}
+		}
+		if bottom > quantum_bottom {
+			cells[quantum_bottom] += fmt.Sprintf(` \vcw{%d}`,
+				bottom-quantum_bottom)
+		}
+	}
+	return cells
+}
+
+// Write the circuit as LaTeX source for the quantikz package, with one
+// quantikz environment per row of folded columns.  Barriers across every
+// qubit are drawn as slices; quantikz cannot draw the others.
+func (c *Circuit) WriteQuantikz(w io.Writer, options *DrawOptions) error {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+	labels := make([]string, 0, c.width+len(c.clbits))
+	for qubit := 0; qubit < c.width; qubit++ {
+		if qubit < len(options.Labels) {
+			labels = append(labels, escapeLaTeX(options.Labels[qubit]))
+		} else {
+			labels = append(labels, fmt.Sprintf("$q_{%d}$", qubit))
+		}
+	}
+	for _, clbit := range c.clbits {
+		labels = append(labels, escapeLaTeX(clbit))
+	}
+	var buf bytes.Buffer
+	for r, row := range foldColumns(c.columns(), options.Fold) {
+		if r > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\\begin{quantikz}\n")
+		columns := make([][]string, len(row))
+		for i, ops := range row {
+			columns[i] = c.quantikzColumn(ops)
+		}
+		for wire, label := range labels {
+			empty := `\qw`
+			if wire >= c.width {
+				empty = `\cw`
+			}
+			fmt.Fprintf(&buf, `\lstick{%s}`, label)
+			for _, cells := range columns {
+				cell := cells[wire]
+				if cell == "" {
+					cell = empty
+				}
+				buf.WriteString(" & " + cell)
+			}
+			buf.WriteString(" & " + empty)
+			if wire < len(labels)-1 {
+				buf.WriteString(` \\`)
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\\end{quantikz}\n")
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// SVG
+
+const (
+	svgRowHeight  = 40
+	svgBoxHeight  = 30
+	svgCharWidth  = 8
+	svgMinColumn  = 40
+	svgRowSpacing = 20
+)
+
+// Get the width of text in an SVG diagram
+func svgTextWidth(text string) int {
+	return utf8.RuneCountInString(text) * svgCharWidth
+}
+
+// Get the label of the box drawn for a gate operation's target with the
+// given index
+func svgBoxLabel(op *Operation, i int) string {
+	label := gateLabel(op.gate)
+	if isBlock(op) || len(op.qubits)-op.gate.controls == 1 {
+		return label
+	}
+	return fmt.Sprintf("%s:%d", label, i-op.gate.controls)
+}
+
+// Get the width of a column of an SVG diagram
+func svgColumnWidth(ops []*Operation) int {
+	width := svgMinColumn
+	for _, op := range ops {
+		if op.kind != gateOp {
+			continue
+		}
+		for i := range op.qubits {
+			if targetShape(op, i) != "box" {
+				continue
+			}
+			if w := svgTextWidth(svgBoxLabel(op, i)) + 20; w > width {
+				width = w
+			}
+		}
+	}
+	return width
+}
+
+// Write a horizontal or vertical wire, doubled if it is classical
+func svgWire(buf *bytes.Buffer, x1 int, y1 int, x2 int, y2 int, classical bool) {
+	if !classical {
+		fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" `+
+			`stroke="black"/>`+"\n", x1, y1, x2, y2)
+		return
+	}
+	dx, dy := 0, 2
+	if x1 == x2 {
+		dx, dy = 2, 0
+	}
+	svgWire(buf, x1-dx, y1-dy, x2-dx, y2-dy, false)
+	svgWire(buf, x1+dx, y1+dy, x2+dx, y2+dy, false)
+}
+
+// Write a box with a centered label
+func svgBox(buf *bytes.Buffer, x int, y int, width int, height int, label string) {
+	fmt.Fprintf(buf, `<rect x="%d" y="%d" width="%d" height="%d" `+
+		`fill="white" stroke="black"/>`+"\n", x-width/2, y-height/2,
+		width, height)
+	if label != "" {
+		fmt.Fprintf(buf, `<text x="%d" y="%d" text-anchor="middle">%s`+
+			"</text>\n", x, y+5, html.EscapeString(label))
+	}
+}
+
+// Write one column of an SVG diagram centered on x, where wire i is at
+// y + i * svgRowHeight
+func (c *Circuit) svgColumn(buf *bytes.Buffer, ops []*Operation, x int, y int, width int) {
+	wire_y := func(wire int) int {
+		return y + wire*svgRowHeight
+	}
+	for _, op := range ops {
+		top, bottom, quantum_bottom := c.opSpan(op)
+		if op.kind == barrierOp {
+			fmt.Fprintf(buf, `<rect x="%d" y="%d" width="10" height="%d" `+
+				`fill="lightgray" opacity="0.6"/>`+"\n", x-5,
+				wire_y(top)-svgRowHeight/2, (bottom-top+1)*svgRowHeight)
+			fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" `+
+				`stroke="gray" stroke-dasharray="4,4"/>`+"\n", x,
+				wire_y(top)-svgRowHeight/2, x,
+				wire_y(bottom)+svgRowHeight/2)
+			continue
+		}
+		// Connections go under the shapes
+		if quantum_bottom > top {
+			svgWire(buf, x, wire_y(top), x, wire_y(quantum_bottom), false)
+		}
+		if bottom > quantum_bottom {
+			svgWire(buf, x, wire_y(quantum_bottom), x, wire_y(bottom), true)
+		}
+		switch op.kind {
+		case measureOp:
+			qy := wire_y(op.qubits[0])
+			svgBox(buf, x, qy, svgBoxHeight, svgBoxHeight, "")
+			fmt.Fprintf(buf, `<path d="M %d %d A 10 10 0 0 1 %d %d" `+
+				`fill="none" stroke="black"/>`+"\n", x-10, qy+6, x+10, qy+6)
+			fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" `+
+				`stroke="black"/>`+"\n", x, qy+6, x+7, qy-9)
+			fmt.Fprintf(buf, `<circle cx="%d" cy="%d" r="4" `+
+				`fill="black"/>`+"\n", x, wire_y(c.clbitWire(op.clbit)))
+		case resetOp:
+			svgBox(buf, x, wire_y(op.qubits[0]), svgBoxHeight,
+				svgBoxHeight, "|0⟩")
+		case gateOp:
+			for i, qubit := range op.qubits {
+				qy := wire_y(qubit)
+				switch targetShape(op, i) {
+				case "control":
+					fmt.Fprintf(buf, `<circle cx="%d" cy="%d" r="5" `+
+						`fill="black"/>`+"\n", x, qy)
+				case "target":
+					fmt.Fprintf(buf, `<circle cx="%d" cy="%d" r="10" `+
+						`fill="white" stroke="black"/>`+"\n", x, qy)
+					svgWire(buf, x-10, qy, x+10, qy, false)
+					svgWire(buf, x, qy-10, x, qy+10, false)
+				case "swap":
+					svgWire(buf, x-7, qy-7, x+7, qy+7, false)
+					svgWire(buf, x-7, qy+7, x+7, qy-7, false)
+				default:
+					if !isBlock(op) {
+						svgBox(buf, x, qy, width-10, svgBoxHeight,
+							svgBoxLabel(op, i))
+					} else if i == op.gate.controls {
+						last := wire_y(op.qubits[len(op.qubits)-1])
+						svgBox(buf, x, (qy+last)/2, width-10,
+							last-qy+svgBoxHeight, svgBoxLabel(op, i))
+					}
+				}
+			}
+		}
+		if op.condition != nil {
+			for i, clbit := range op.condition.clbits {
+				fill := "white"
+				if op.condition.value>>uint(i)&1 == 1 {
+					fill = "black"
+				}
+				fmt.Fprintf(buf, `<circle cx="%d" cy="%d" r="5" `+
+					`fill="%s" stroke="black"/>`+"\n", x,
+					wire_y(c.clbitWire(clbit)), fill)
+			}
+		}
+	}
+}
+
+// Write the circuit as a standalone SVG image, with folded rows of columns
+// stacked from top to bottom
+func (c *Circuit) WriteSVG(w io.Writer, options *DrawOptions) error {
+	if options == nil {
+		options = &DrawOptions{}
+	}
+	labels := c.wireLabels(options)
+	wires := len(labels)
+	label_width := 0
+	for _, label := range labels {
+		if lw := svgTextWidth(label); lw > label_width {
+			label_width = lw
+		}
+	}
+	label_width += 20
+	rows := foldColumns(c.columns(), options.Fold)
+	widths := make([][]int, len(rows))
+	width := 0
+	for r, row := range rows {
+		row_width := label_width + 20
+		for _, ops := range row {
+			widths[r] = append(widths[r], svgColumnWidth(ops))
+			row_width += widths[r][len(widths[r])-1]
+		}
+		if row_width > width {
+			width = row_width
+		}
+	}
+	row_height := wires*svgRowHeight + svgRowSpacing
+	height := len(rows)*row_height - svgRowSpacing + svgRowHeight/2
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" `+
+		`width="%d" height="%d" font-family="sans-serif" font-size="14">`+
+		"\n", width, height)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="white"/>`+"\n",
+		width, height)
+	for r, row := range rows {
+		y := r*row_height + svgRowHeight/2 + svgRowSpacing/2
+		for wire, label := range labels {
+			wy := y + wire*svgRowHeight
+			fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="end">%s`+
+				"</text>\n", label_width-10, wy+5,
+				html.EscapeString(label))
+			svgWire(&buf, label_width, wy, width-10, wy, wire >= c.width)
+		}
+		x := label_width + 10
+		for i, ops := range row {
+			c.svgColumn(&buf, ops, x+widths[r][i]/2, y, widths[r][i])
+			x += widths[r][i]
+		}
+	}
+	buf.WriteString("</svg>\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
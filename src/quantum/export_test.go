@@ -0,0 +1,142 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteQuantikz(t *testing.T) {
+	c := NewCircuit(2, "m")
+	c.Apply(NewHadamardGate(1), 0)
+	c.Apply(NewCNOTGate(), 0, 1)
+	c.Barrier()
+	c.Apply(NewPhaseShiftGate(.5), 1)
+	c.Measure(1, "m")
+	var buf bytes.Buffer
+	if err := c.WriteQuantikz(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := `\begin{quantikz}
+\lstick{$q_{0}$} & \gate{H} & \ctrl{1} & \qw \slice{} & \qw & \qw & \qw \\
+\lstick{$q_{1}$} & \qw & \targ{} & \qw & \gate{P(0.5)} & \meter{} \vcw{1} & \qw \\
+\lstick{m} & \cw & \cw & \cw & \cw & \cw & \cw
+\end{quantikz}
+`
+	if buf.String() != want {
+		t.Errorf("Bad quantikz:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	options := &DrawOptions{Labels: []string{`a_1$`, `b`}, Fold: 2}
+	if err := c.WriteQuantikz(&buf, options); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), `\begin{quantikz}`); n != 3 {
+		t.Errorf("Bad number of folded rows %d, want 3", n)
+	}
+	if !strings.HasPrefix(buf.String(), "\\begin{quantikz}\n\\lstick{a\\_1\\$}") {
+		t.Errorf("Qubit label missing from:\n%s", buf.String())
+	}
+}
+
+func TestWriteQuantikz_PartialBarrier(t *testing.T) {
+	c := NewCircuit(3)
+	c.Barrier(0, 2)
+	c.Barrier(2, 1, 0)
+	var buf bytes.Buffer
+	if err := c.WriteQuantikz(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), `\slice`); n != 1 {
+		t.Errorf("Bad number of slices %d, want 1:\n%s", n, buf.String())
+	}
+}
+
+func TestLaTeXGateLabel(t *testing.T) {
+	gate := NewQFTGate(2).Adjoint().Power(2)
+	if label := latexGateLabel(gate); label != `\mathrm{QFT}^{\dagger 2}` {
+		t.Errorf("Bad label %q", label)
+	}
+	if label := latexGateLabel(NewQFTGate(2).Adjoint()); label !=
+		`\mathrm{QFT}^{\dagger}` {
+		t.Errorf("Bad label %q", label)
+	}
+	// Text-mode escapes such as \^{} do not compile in math mode
+	gate = NewPauliXGate().SetName(`a\b~c_d`).Power(2)
+	if label := latexGateLabel(gate); label !=
+		`\mathrm{a\backslash{}b\sim{}c\_d}^{2}` {
+		t.Errorf("Bad label %q", label)
+	}
+}
+
+// Helper function for testing. Counts the elements of each kind in an SVG
+// document, failing if it is not well-formed.
+func countSVGElements(t *testing.T, data []byte) map[string]int {
+	counts := make(map[string]int)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return counts
+		}
+		if err != nil {
+			t.Fatalf("Bad SVG: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			counts[start.Name.Local]++
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	c := NewCircuit(2, "m")
+	c.Apply(NewHadamardGate(1), 0)
+	c.Apply(NewCNOTGate(), 0, 1)
+	c.Barrier()
+	c.Apply(NewPhaseShiftGate(.5), 1)
+	c.Measure(1, "m")
+	c.Apply(NewPauliXGate(), 0).CIf(1, "m")
+	var buf bytes.Buffer
+	options := &DrawOptions{Labels: []string{"a<b"}}
+	if err := c.WriteSVG(&buf, options); err != nil {
+		t.Fatal(err)
+	}
+	counts := countSVGElements(t, buf.Bytes())
+	if counts["svg"] != 1 || counts["path"] != 1 {
+		t.Errorf("Bad SVG elements %v", counts)
+	}
+	for _, text := range []string{">a&lt;b<", ">q1<", ">m<", ">P(0.5)<",
+		"stroke-dasharray"} {
+		if !bytes.Contains(buf.Bytes(), []byte(text)) {
+			t.Errorf("SVG does not contain %q", text)
+		}
+	}
+
+	buf.Reset()
+	if err := c.WriteSVG(&buf, &DrawOptions{Fold: 2}); err != nil {
+		t.Fatal(err)
+	}
+	// Every folded row repeats the labels
+	if n := bytes.Count(buf.Bytes(), []byte(">q0<")); n != 3 {
+		t.Errorf("Bad number of folded rows %d, want 3", n)
+	}
+}
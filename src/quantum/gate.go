@@ -30,12 +30,43 @@ type Gate struct {
 	get   func(row int, col int) complex128
 	width func() int
 	bits  func() int
+
+	// A short label for the gate, used when recording and drawing circuits.
+	name string
+
+	// Parameters such as rotation angles that the gate was built from.
+	params []float64
+
+	// Set when the parameters are phases, so that the adjoint and powers
+	// of the gate are the same gate with scaled parameters.
+	phaseParams bool
+
+	// The number of leading targets that are controls.  The name and
+	// parameters describe the gate applied to the remaining targets.
+	controls int
+
+	// An optional fast path that applies the gate to the given targets of a
+	// register's amplitudes without evaluating the matrix.
+	apply func(amplitudes []complex128, targets []int) []complex128
+
+	// An optional fast path like apply that overwrites the amplitudes
+	// instead of allocating new ones.
+	applyInPlace func(amplitudes []complex128, targets []int)
+
+	// The diagonal of the matrix, for gates that are known to be diagonal.
+	diagonal []complex128
+
+	// The image of each basis state, for gates that are known to be
+	// permutations.
+	permutation []int
 }
 
+// Compute one element of gate^dagger * gate and report whether it differs
+// from the identity
 func (gate *Gate) computeSquareElement(row int, col int, c chan bool) {
 	sum := complex(0, 0)
 	for i := 0; i < gate.width(); i++ {
-		sum += gate.get(row, i) * gate.get(i, col)
+		sum += cmplx.Conj(gate.get(i, row)) * gate.get(i, col)
 	}
 	if row == col {
 		if closeEnough(sum, complex(1, 0)) {
@@ -65,16 +96,39 @@ func (gate *Gate) IsUnitary() bool {
 	return true
 }
 
+// The matrix of a gate, which user-defined gates implement.  Element (row,
+// col) maps basis state col to basis state row, with target i as bit i.
+type GateImpl interface {
+	Get(row int, col int) complex128
+	Bits() int
+}
+
+// Implemented by gate implementations that can apply themselves to the given
+// targets of a register's amplitudes faster than by matrix multiplication.
+// The result must match the matrix given by Get.
+type Applier interface {
+	ApplyAmplitudes(amplitudes []complex128, targets []int) []complex128
+}
+
+// Make a gate from a user-defined implementation.  If the implementation is
+// also an Applier, the gate is applied with it.  Unitarity is not checked.
+func NewGate(impl GateImpl) *Gate {
+	gate := NewFuncGateNoCheck(impl.Get, impl.Bits())
+	if applier, ok := impl.(Applier); ok {
+		gate.apply = applier.ApplyAmplitudes
+	}
+	return gate
+}
+
 func NewFuncGateNoCheck(f func(row int, col int) complex128, bits int) *Gate {
-	return &Gate{f, func() int {
+	return &Gate{get: f, width: func() int {
 		return 1 << uint(bits)
 	},
-		func() int {
+		bits: func() int {
 			return bits
 		}}
 }
 
-
 func NewFuncGate(f func(row int, col int) complex128, bits int) *Gate {
 	gate := NewFuncGateNoCheck(f, bits)
 	if !gate.IsUnitary() {
@@ -83,6 +137,173 @@ func NewFuncGate(f func(row int, col int) complex128, bits int) *Gate {
 	return gate
 }
 
+// Accessor for the name of a gate.  Unnamed gates are called "U".
+func (gate *Gate) Name() string {
+	if gate.name == "" {
+		return "U"
+	}
+	return gate.name
+}
+
+// Give the gate a name, returning the gate itself
+func (gate *Gate) SetName(name string) *Gate {
+	gate.name = name
+	return gate
+}
+
+// Accessor for the parameters of a gate
+func (gate *Gate) Params() []float64 {
+	params := make([]float64, len(gate.params))
+	copy(params, gate.params)
+	return params
+}
+
+// Accessor for the number of leading targets that control the gate
+func (gate *Gate) Controls() int {
+	return gate.controls
+}
+
+// Accessor for an element of a gate's matrix
+func (gate *Gate) Get(row int, col int) complex128 {
+	return gate.get(row, col)
+}
+
+// Accessor for the number of qubits a gate acts on
+func (gate *Gate) Bits() int {
+	return gate.bits()
+}
+
+// Give a gate derived from another, such as its adjoint or a power, the
+// other's controls and parameters.  Phase parameters are multiplied by scale
+// and keep the other's name; otherwise the gate is named with the given
+// suffix, unless keepName is set.
+func (gate *Gate) derive(from *Gate, scale float64, suffix string, keepName bool) *Gate {
+	gate.controls = from.controls
+	gate.params = from.params
+	gate.phaseParams = from.phaseParams
+	if from.phaseParams {
+		gate.params = make([]float64, len(from.params))
+		for i, param := range from.params {
+			gate.params[i] = scale * param
+		}
+		keepName = true
+	}
+	if keepName {
+		gate.name = from.name
+	} else {
+		gate.SetName(from.Name() + suffix)
+	}
+	return gate
+}
+
+// Get the inverse (conjugate transpose) of a gate.  The adjoint of a diagonal
+// or permutation gate that is its own inverse keeps the gate's name.
+func (gate *Gate) Adjoint() *Gate {
+	if gate.diagonal != nil {
+		diagonal := make([]complex128, len(gate.diagonal))
+		involution := true
+		for i, d := range gate.diagonal {
+			diagonal[i] = cmplx.Conj(d)
+			involution = involution && closeEnough(diagonal[i], d)
+		}
+		return newDiagonalGateNoCheck(diagonal).derive(gate, -1, "†",
+			involution)
+	}
+	if gate.permutation != nil {
+		inverse := make([]int, len(gate.permutation))
+		for x, y := range gate.permutation {
+			inverse[y] = x
+		}
+		involution := true
+		for x, y := range gate.permutation {
+			involution = involution && inverse[x] == y
+		}
+		return NewPermutationGate(func(x int) int {
+			return inverse[x]
+		},
+			gate.bits()).derive(gate, -1, "†", involution)
+	}
+	return NewFuncGateNoCheck(func(row int, col int) complex128 {
+		return cmplx.Conj(gate.get(col, row))
+	},
+		gate.bits()).derive(gate, -1, "†", false)
+}
+
+// Get the gate applied n times in a row.  The matrix is computed once, by
+// repeated squaring.
+func (gate *Gate) Power(n int) *Gate {
+	if n < 0 {
+		return gate.Adjoint().Power(-n)
+	}
+	suffix := fmt.Sprintf("^%d", n)
+	if gate.diagonal != nil {
+		diagonal := make([]complex128, len(gate.diagonal))
+		for i, d := range gate.diagonal {
+			diagonal[i] = cmplx.Pow(d, complex(float64(n), 0))
+		}
+		return newDiagonalGateNoCheck(diagonal).derive(gate, float64(n),
+			suffix, false)
+	}
+	if gate.permutation != nil {
+		// Compose the table with itself by repeated squaring
+		result := make([]int, len(gate.permutation))
+		for x := range result {
+			result[x] = x
+		}
+		square := gate.permutation
+		for k := n; k > 0; k >>= 1 {
+			if k&1 == 1 {
+				next := make([]int, len(result))
+				for x, y := range result {
+					next[x] = square[y]
+				}
+				result = next
+			}
+			if k > 1 {
+				next := make([]int, len(square))
+				for x, y := range square {
+					next[x] = square[y]
+				}
+				square = next
+			}
+		}
+		return NewPermutationGate(func(x int) int {
+			return result[x]
+		},
+			gate.bits()).derive(gate, float64(n), suffix, false)
+	}
+	width := gate.width()
+	result := make([]complex128, width*width)
+	for i := 0; i < width; i++ {
+		result[i*width+i] = 1
+	}
+	square := gate.elements()
+	for k := n; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result = matMul(result, square, width, width, width)
+		}
+		if k > 1 {
+			square = matMul(square, square, width, width, width)
+		}
+	}
+	return NewFuncGateNoCheck(func(row int, col int) complex128 {
+		return result[row*width+col]
+	},
+		gate.bits()).derive(gate, float64(n), suffix, false)
+}
+
+// Get the elements of a gate's matrix in row-major order
+func (gate *Gate) elements() []complex128 {
+	width := gate.width()
+	elements := make([]complex128, width*width)
+	for row := 0; row < width; row++ {
+		for col := 0; col < width; col++ {
+			elements[row*width+col] = gate.get(row, col)
+		}
+	}
+	return elements
+}
+
 func NewArrayGate(arr []complex128) *Gate {
 	width := int(math.Sqrt(float64(len(arr))))
 	return NewFuncGate(func(row int, col int) complex128 {
@@ -99,14 +320,75 @@ func NewRealArrayGate(arr []float64) *Gate {
 	return NewArrayGate(new_arr)
 }
 
-func NewClassicalGate(f func(x int) int, bits int) *Gate {
-	return NewFuncGate(func(row int, col int) complex128 {
-		if f(col) == row {
+// Make the gate |x> -> |f(x)>, where f must be a permutation of the values of
+// bits qubits.  The gate is applied by moving amplitudes rather than by
+// multiplying its matrix.
+func NewPermutationGate(f func(x int) int, bits int) *Gate {
+	width := 1 << uint(bits)
+	table := make([]int, width)
+	seen := make([]bool, width)
+	for x := range table {
+		y := f(x)
+		if y < 0 || y >= width || seen[y] {
+			panic(fmt.Sprintf("Function is not a permutation of %d bits",
+				bits))
+		}
+		table[x] = y
+		seen[y] = true
+	}
+	gate := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		if table[col] == row {
 			return complex(1, 0)
 		}
 		return complex(0, 0)
 	},
 		bits)
+	gate.apply = func(amplitudes []complex128, targets []int) []complex128 {
+		return permuteAmplitudes(amplitudes, targets, func(x int) int {
+			return table[x]
+		})
+	}
+	gate.permutation = table
+	return gate
+}
+
+func newDiagonalGateNoCheck(diagonal []complex128) *Gate {
+	bits, ok := log2Exact(len(diagonal))
+	if !ok {
+		panic(fmt.Sprintf("Diagonal of length %d is not a power of 2",
+			len(diagonal)))
+	}
+	gate := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		if row != col {
+			return complex(0, 0)
+		}
+		return diagonal[row]
+	},
+		bits)
+	gate.apply = func(amplitudes []complex128, targets []int) []complex128 {
+		return scaleAmplitudes(amplitudes, targets, func(x int) complex128 {
+			return diagonal[x]
+		})
+	}
+	gate.diagonal = diagonal
+	return gate
+}
+
+// Make the gate with the given diagonal, whose entries must all have
+// magnitude 1.  The gate is applied by scaling amplitudes rather than by
+// multiplying its matrix.
+func NewDiagonalGate(diagonal []complex128) *Gate {
+	for i, d := range diagonal {
+		if math.Abs(cmplx.Abs(d)-1) > .0000000001 {
+			panic(fmt.Sprintf("Diagonal entry %d has magnitude %f, not 1",
+				i, cmplx.Abs(d)))
+		}
+	}
+	return newDiagonalGateNoCheck(diagonal)
+}
+
+func NewClassicalGate(f func(x int) int, bits int) *Gate {
+	return NewPermutationGate(f, bits)
 }
 
 func stateIndexForTarget(application int, target_value int, size int, targets []int) int {
@@ -137,42 +419,84 @@ type indexAmplitude struct {
 }
 
 // Compute one row of matrix multiplication
-func (gate *Gate) computeRow(qreg *QReg, app int, row int, targets []int, c chan indexAmplitude) {
+func (gate *Gate) computeRow(amplitudes []complex128, size int, app int, row int, targets []int, c chan indexAmplitude) {
 	sum := complex128(complex(0, 0))
 	for col := 0; col < gate.width(); col++ {
-		index := stateIndexForTarget(app, col, qreg.width, targets)
-		sum += gate.get(row, col) * qreg.amplitudes[index]
+		index := stateIndexForTarget(app, col, size, targets)
+		sum += gate.get(row, col) * amplitudes[index]
 	}
-	index := stateIndexForTarget(app, row, qreg.width, targets)
+	index := stateIndexForTarget(app, row, size, targets)
 	c <- indexAmplitude{index, sum}
 }
 
-// Apply an arbitrary matrix to a quantum register
-// len(matrix) == 4 ** len(targets)
-func (gate *Gate) Apply(qreg *QReg, targets []int) {
-	// Verify that all the targets are valid
-	for _, target := range targets {
-		if target >= qreg.width {
-			panic(fmt.Sprintf("%d is not a valid target", target))
-		}
+// Apply the gate to the given targets of a register's amplitudes, returning
+// the new amplitudes.  This uses the gate's fast path if it has one and
+// multiplies by its matrix otherwise.
+func (gate *Gate) ApplyAmplitudes(amplitudes []complex128, targets []int) []complex128 {
+	if gate.apply != nil {
+		return gate.apply(amplitudes, targets)
 	}
-
-	num_apps := 1 << uint(qreg.width-len(targets))
-	new_states := make([]complex128, len(qreg.amplitudes))
+	if gate.applyInPlace != nil {
+		new_states := make([]complex128, len(amplitudes))
+		copy(new_states, amplitudes)
+		gate.applyInPlace(new_states, targets)
+		return new_states
+	}
+	size, _ := log2Exact(len(amplitudes))
+	num_apps := 1 << uint(size-len(targets))
+	new_states := make([]complex128, len(amplitudes))
 	// Each application of the matrix
 	// app is the binary representation of the non-target states
 	for app := 0; app < num_apps; app++ {
 		// Each row of the matrix
 		c := make(chan indexAmplitude)
 		for row := 0; row < gate.width(); row++ {
-			go gate.computeRow(qreg, app, row, targets, c)
+			go gate.computeRow(amplitudes, size, app, row, targets, c)
 		}
 		for row := 0; row < gate.width(); row++ {
 			ia := <-c
 			new_states[ia.index] = ia.amplitude
 		}
 	}
-	qreg.amplitudes = new_states
+	return new_states
+}
+
+// Apply an arbitrary matrix to a quantum register
+// len(matrix) == 4 ** len(targets)
+func (gate *Gate) Apply(qreg *QReg, targets []int) {
+	// Verify that all the targets are valid
+	for _, target := range targets {
+		if target >= qreg.width {
+			panic(fmt.Sprintf("%d is not a valid target", target))
+		}
+	}
+	if gate.applyInPlace != nil {
+		gate.applyInPlace(qreg.amplitudes, targets)
+		return
+	}
+	qreg.amplitudes = gate.ApplyAmplitudes(qreg.amplitudes, targets)
+}
+
+// Apply a permutation f of the values of the given targets to a register's
+// amplitudes, so the amplitude of value x moves to value f(x)
+func permuteAmplitudes(amplitudes []complex128, targets []int, f func(x int) int) []complex128 {
+	mask := spreadValue(1<<uint(len(targets))-1, targets)
+	new_states := make([]complex128, len(amplitudes))
+	for state, amp := range amplitudes {
+		value := f(subValue(state, targets))
+		new_states[state&^mask|spreadValue(value, targets)] = amp
+	}
+	return new_states
+}
+
+// Multiply each of a register's amplitudes by d(x), where x is the value of
+// the given targets
+func scaleAmplitudes(amplitudes []complex128, targets []int, d func(x int) complex128) []complex128 {
+	new_states := make([]complex128, len(amplitudes))
+	for state, amp := range amplitudes {
+		new_states[state] = amp * d(subValue(state, targets))
+	}
+	return new_states
 }
 
 func (gate *Gate) ApplyRange(qreg *QReg, target_range_start int) {
@@ -187,13 +511,26 @@ func (gate *Gate) ApplyReg(qreg *QReg) {
 	gate.ApplyRange(qreg, 0)
 }
 
+func (gate *Gate) ApplySub(sub *SubReg) {
+	if sub.Width() != gate.bits() {
+		panic(fmt.Sprintf("%d-bit gate applied to %d-bit sub-register",
+			gate.bits(), sub.Width()))
+	}
+	gate.Apply(sub.qreg, sub.live())
+}
+
 func (gate *Gate) Print() {
+	printMatrix(gate.get, gate.width())
+}
+
+// Print a square matrix with aligned columns
+func printMatrix(get func(row int, col int) complex128, width int) {
 	// Get column sizes
-	sizes := make([]int, gate.width())
-	for col := 0; col < gate.width(); col++ {
+	sizes := make([]int, width)
+	for col := 0; col < width; col++ {
 		max := 0
-		for row := 0; row < gate.width(); row++ {
-			l := len(fmt.Sprintf("%+f", gate.get(row, col)))
+		for row := 0; row < width; row++ {
+			l := len(fmt.Sprintf("%+f", get(row, col)))
 			if l > max {
 				max = l
 			}
@@ -204,9 +541,9 @@ func (gate *Gate) Print() {
 		sizes[col] = max
 	}
 	// Print each row
-	for row := 0; row < gate.width(); row++ {
-		for col := 0; col < gate.width(); col++ {
-			str := fmt.Sprintf("%+f", gate.get(row, col))
+	for row := 0; row < width; row++ {
+		for col := 0; col < width; col++ {
+			str := fmt.Sprintf("%+f", get(row, col))
 			for i := len(str); i < sizes[col]; i++ {
 				fmt.Print(" ")
 			}
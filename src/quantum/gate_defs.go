@@ -18,10 +18,164 @@ package quantum
 
 import (
 	"math"
+	"math/cmplx"
 )
 
+// Pauli Gates
+
+func NewPauliXGate() *Gate {
+	return NewPermutationGate(func(x int) int {
+		return x ^ 1
+	},
+		1).SetName("X")
+}
+
+func NewPauliYGate() *Gate {
+	return NewArrayGate([]complex128{
+		0, complex(0, -1),
+		complex(0, 1), 0,
+	}).SetName("Y")
+}
+
+func NewPauliZGate() *Gate {
+	return NewDiagonalGate([]complex128{1, -1}).SetName("Z")
+}
+
+func PauliX(qreg *QReg, target int) {
+	NewPauliXGate().Apply(qreg, []int{target})
+}
+
+func PauliY(qreg *QReg, target int) {
+	NewPauliYGate().Apply(qreg, []int{target})
+}
+
+func PauliZ(qreg *QReg, target int) {
+	NewPauliZGate().Apply(qreg, []int{target})
+}
+
+// Phase Shift Gate
+
+// Make the gate diag(1, e**(i theta))
+func NewPhaseShiftGate(theta float64) *Gate {
+	gate := NewDiagonalGate([]complex128{
+		1, cmplx.Exp(complex(0, theta)),
+	}).SetName("P")
+	gate.params = []float64{theta}
+	gate.phaseParams = true
+	return gate
+}
+
+func PhaseShift(qreg *QReg, target int, theta float64) {
+	NewPhaseShiftGate(theta).Apply(qreg, []int{target})
+}
+
+// Swap Gate
+
+func NewSwapGate() *Gate {
+	return NewPermutationGate(func(x int) int {
+		return x>>1 | (x&1)<<1
+	},
+		2).SetName("SWAP")
+}
+
+func Swap(qreg *QReg, a int, b int) {
+	NewSwapGate().Apply(qreg, []int{a, b})
+}
+
+// Controlled Gates
+
+// Make a gate that applies the given gate to its last targets when its first
+// controls targets are all 1.  A controlled diagonal or permutation gate is
+// itself diagonal or a permutation.
+func NewControlledGate(gate *Gate, controls int) *Gate {
+	mask := (1 << uint(controls)) - 1
+	if gate.permutation != nil {
+		controlled := NewPermutationGate(func(x int) int {
+			if x&mask != mask {
+				return x
+			}
+			return gate.permutation[x>>uint(controls)]<<uint(controls) | mask
+		},
+			gate.bits()+controls).SetName(gate.Name())
+		controlled.params = gate.params
+		controlled.phaseParams = gate.phaseParams
+		controlled.controls = gate.controls + controls
+		return controlled
+	}
+	if gate.diagonal != nil {
+		diagonal := make([]complex128, len(gate.diagonal)<<uint(controls))
+		for x := range diagonal {
+			diagonal[x] = complex(1, 0)
+			if x&mask == mask {
+				diagonal[x] = gate.diagonal[x>>uint(controls)]
+			}
+		}
+		controlled := newDiagonalGateNoCheck(diagonal).SetName(gate.Name())
+		controlled.params = gate.params
+		controlled.phaseParams = gate.phaseParams
+		controlled.controls = gate.controls + controls
+		return controlled
+	}
+	controlled := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		if row&mask != col&mask {
+			return complex(0, 0)
+		}
+		if col&mask != mask {
+			if row == col {
+				return complex(1, 0)
+			}
+			return complex(0, 0)
+		}
+		return gate.get(row>>uint(controls), col>>uint(controls))
+	},
+		gate.bits()+controls).SetName(gate.Name())
+	controlled.params = gate.params
+	controlled.phaseParams = gate.phaseParams
+	controlled.controls = gate.controls + controls
+	return controlled
+}
+
+func NewCNOTGate() *Gate {
+	return NewControlledGate(NewPauliXGate(), 1)
+}
+
+func CNOT(qreg *QReg, control int, target int) {
+	NewCNOTGate().Apply(qreg, []int{control, target})
+}
+
+// Toffoli Gate
+
+// Make the doubly-controlled NOT gate, whose first two targets are controls
+func NewToffoliGate() *Gate {
+	return NewControlledGate(NewPauliXGate(), 2)
+}
+
+func Toffoli(qreg *QReg, a int, b int, target int) {
+	NewToffoliGate().Apply(qreg, []int{a, b, target})
+}
+
 // Hadamard Gate
 
+// Apply the Hadamard transform in place to the given targets of a register's
+// amplitudes with the fast Walsh-Hadamard transform, one butterfly pass per
+// target, in O(len(targets) * len(amplitudes)) time
+func walshHadamard(amplitudes []complex128, targets []int) {
+	for _, target := range targets {
+		bit := 1 << uint(target)
+		for state := range amplitudes {
+			if state&bit != 0 {
+				continue
+			}
+			a, b := amplitudes[state], amplitudes[state|bit]
+			amplitudes[state], amplitudes[state|bit] = a+b, a-b
+		}
+	}
+	norm := complex(1/math.Sqrt(float64(int(1)<<uint(len(targets)))), 0)
+	for state := range amplitudes {
+		amplitudes[state] *= norm
+	}
+}
+
 func NewHadamardGate(bits int) *Gate {
 	d := float64(int(1 << uint(bits>>1)))
 	if bits&1 == 1 {
@@ -29,7 +183,7 @@ func NewHadamardGate(bits int) *Gate {
 	}
 	p := complex(1.0/d, 0)
 	n := -p
-	return NewFuncGateNoCheck(func(row int, col int) complex128 {
+	gate := NewFuncGateNoCheck(func(row int, col int) complex128 {
 		// Calculate (-1)**<i,j> / sqrt(2**n)
 		par := 0
 		for anded := row & col; anded > 0; anded >>= 1 {
@@ -40,7 +194,9 @@ func NewHadamardGate(bits int) *Gate {
 		}
 		return p
 	},
-		bits)
+		bits).SetName("H")
+	gate.applyInPlace = walshHadamard
+	return gate
 }
 
 func Hadamard(qreg *QReg, target int) {
@@ -68,7 +224,7 @@ func NewDiffusionGate(bits int) *Gate {
 		}
 		return a2
 	},
-		bits)
+		bits).SetName("D")
 }
 
 func Diffusion(qreg *QReg, target int) {
@@ -18,6 +18,7 @@ package quantum
 
 import (
 	"math"
+	"math/cmplx"
 	"testing"
 )
 
@@ -116,3 +117,118 @@ func TestDiffusionGate_4x4(t *testing.T) {
 		}
 	}
 }
+
+func TestControlledGate(t *testing.T) {
+	cnot := NewCNOTGate()
+	if cnot.Controls() != 1 || cnot.Name() != "X" {
+		t.Errorf("Bad controlled gate metadata %d, %q",
+			cnot.Controls(), cnot.Name())
+	}
+	// The control is target 0, the least significant bit
+	arr := []complex128{
+		1, 0, 0, 0,
+		0, 0, 0, 1,
+		0, 0, 1, 0,
+		0, 1, 0, 0,
+	}
+	for i := 0; i < 16; i++ {
+		a := i / 4
+		b := i % 4
+		v := cnot.get(a, b)
+		if v != arr[i] {
+			t.Errorf("Bad value in CNOT matrix at index "+
+				"%d, %d = %f; want %f",
+				a, b, v, arr[i])
+		}
+	}
+	toffoli := NewControlledGate(cnot, 1)
+	if toffoli.Controls() != 2 || !toffoli.IsUnitary() {
+		t.Error("Bad doubly controlled gate")
+	}
+	qreg := NewQReg(3, 6)
+	toffoli.Apply(qreg, []int{1, 2, 0})
+	if !verifyBasisState(qreg, 7) {
+		t.Error("Expected |111>.")
+	}
+}
+
+// The Pauli X, SWAP and controlled permutation gates are permutation gates,
+// and must match their dense matrices
+func TestPermutationGateDefs(t *testing.T) {
+	for _, test := range []struct {
+		gate *Gate
+		arr  []float64
+	}{
+		{NewPauliXGate(), []float64{
+			0, 1,
+			1, 0,
+		}},
+		{NewSwapGate(), []float64{
+			1, 0, 0, 0,
+			0, 0, 1, 0,
+			0, 1, 0, 0,
+			0, 0, 0, 1,
+		}},
+		{NewCNOTGate(), []float64{
+			1, 0, 0, 0,
+			0, 0, 0, 1,
+			0, 0, 1, 0,
+			0, 1, 0, 0,
+		}},
+	} {
+		if test.gate.permutation == nil {
+			t.Errorf("%s is not a permutation gate", test.gate.Name())
+		}
+		if !test.gate.EqualsUpToPhase(NewRealArrayGate(test.arr)) {
+			t.Errorf("Bad %s matrix", test.gate.Name())
+		}
+		verifyFastPath(t, test.gate, newIrregularQReg(3),
+			qubitRange(3-test.gate.Bits(), 3))
+	}
+	toffoli := NewToffoliGate()
+	if toffoli.permutation == nil || toffoli.Controls() != 2 {
+		t.Error("Toffoli is not a doubly controlled permutation gate")
+	}
+	verifyFastPath(t, toffoli, newIrregularQReg(4), []int{3, 0, 2})
+}
+
+func TestHadamardGate_FastPath(t *testing.T) {
+	verifyFastPath(t, NewHadamardGate(3), newIrregularQReg(5), []int{4, 1, 2})
+	// HadamardRange must match the dense gate on the same range
+	qreg := newIrregularQReg(5)
+	HadamardRange(qreg, 1, 4)
+	want := newIrregularQReg(5)
+	dense := *NewHadamardGate(3)
+	dense.apply = nil
+	dense.applyInPlace = nil
+	dense.ApplyRange(want, 1)
+	for i, amp := range want.amplitudes {
+		if cmplx.Abs(qreg.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Bad HadamardRange amplitude for state %d = %+f, "+
+				"want %+f", i, qreg.amplitudes[i], amp)
+		}
+	}
+	// The transform is done in place by Apply, but ApplyAmplitudes must
+	// leave its argument alone
+	amplitudes := qreg.amplitudes
+	NewHadamardGate(1).Apply(qreg, []int{0})
+	if &qreg.amplitudes[0] != &amplitudes[0] {
+		t.Error("Hadamard gate allocated new amplitudes")
+	}
+	before := append([]complex128{}, amplitudes...)
+	NewHadamardGate(1).ApplyAmplitudes(amplitudes, []int{0})
+	for i, amp := range before {
+		if amplitudes[i] != amp {
+			t.Fatal("ApplyAmplitudes overwrote its argument")
+		}
+	}
+}
+
+func TestHadamardReg_Large(t *testing.T) {
+	qreg := NewQReg(16, 0)
+	HadamardReg(qreg)
+	HadamardReg(qreg)
+	if p := qreg.StateProb(0); math.Abs(p-1) > 1e-9 {
+		t.Errorf("Bad probability of |0> after H H = %f, want 1", p)
+	}
+}
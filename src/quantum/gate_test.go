@@ -0,0 +1,254 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Gates that are unitary but not their own inverse, such as S and T, must be
+// accepted
+func TestIsUnitary(t *testing.T) {
+	c := NewCircuit(1)
+	c.Apply(NewHadamardGate(1), 0)
+	c.Apply(NewPhaseShiftGate(math.Pi/2), 0)
+	for _, gate := range []*Gate{
+		NewPhaseShiftGate(0.3),
+		NewPhaseShiftGate(math.Pi / 4),
+		c.ToGate(),
+	} {
+		if !gate.IsUnitary() {
+			t.Errorf("Unitary gate %s rejected", gate.Name())
+		}
+	}
+	notUnitary := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		if row == 0 {
+			return 1
+		}
+		return 0
+	},
+		1)
+	if notUnitary.IsUnitary() {
+		t.Error("Non-unitary gate accepted")
+	}
+}
+
+func TestPermutationGate(t *testing.T) {
+	gate := NewPermutationGate(func(x int) int {
+		return (5*x + 3) % 8
+	}, 3)
+	if !gate.IsUnitary() {
+		t.Error("Permutation gate is not unitary")
+	}
+	verifyFastPath(t, gate, newIrregularQReg(4), []int{2, 0, 3})
+}
+
+func TestPermutationGate_Large(t *testing.T) {
+	bits := 14
+	increment := NewPermutationGate(func(x int) int {
+		return (x + 1) % (1 << uint(bits))
+	}, bits)
+	qreg := NewQReg(bits+1, 1<<uint(bits)-1)
+	increment.ApplyReg(qreg)
+	if v := qreg.Measure(); v != 0 {
+		t.Errorf("Bad increment of %d = %d, want 0", 1<<uint(bits)-1, v)
+	}
+}
+
+func TestPermutationGate_NotBijective(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Non-bijective function accepted as a permutation")
+		}
+	}()
+	NewPermutationGate(func(x int) int { return x / 2 }, 2)
+}
+
+func TestDiagonalGate(t *testing.T) {
+	gate := NewDiagonalGate([]complex128{
+		1, complex(0, 1), -1, complex(.6, .8),
+	})
+	if !gate.IsUnitary() {
+		t.Error("Diagonal gate is not unitary")
+	}
+	verifyFastPath(t, gate, newIrregularQReg(3), []int{2, 0})
+	// Compare with the cubes of the diagonal, built without Power
+	cube := NewArrayGate([]complex128{
+		1, 0, 0, 0,
+		0, complex(0, -1), 0, 0,
+		0, 0, -1, 0,
+		0, 0, 0, complex(-.936, .352),
+	})
+	if !gate.Power(3).EqualsUpToPhase(cube) {
+		t.Error("Bad power of diagonal gate")
+	}
+	if !gate.Power(-3).EqualsUpToPhase(cube.Adjoint()) {
+		t.Error("Bad negative power of diagonal gate")
+	}
+}
+
+func TestDiagonalGate_Controlled(t *testing.T) {
+	gate := NewPhaseShiftGate(math.Pi / 3)
+	controlled := NewControlledGate(gate, 2)
+	if controlled.diagonal == nil {
+		t.Fatal("Controlled diagonal gate is not diagonal")
+	}
+	// Compare with the controlled form of the same gate without its
+	// diagonal
+	dense := *gate
+	dense.diagonal = nil
+	if !controlled.EqualsUpToPhase(NewControlledGate(&dense, 2)) {
+		t.Error("Bad controlled diagonal gate")
+	}
+	verifyFastPath(t, controlled, newIrregularQReg(4), []int{3, 1, 0})
+}
+
+func TestDiagonalGate_NotUnitary(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Non-unitary diagonal accepted")
+		}
+	}()
+	NewDiagonalGate([]complex128{1, .5})
+}
+
+// A user-defined gate that adds 1 modulo 2**bits and counts how often it
+// applies itself
+type testIncrement struct {
+	bits    int
+	applied int
+}
+
+func (inc *testIncrement) Get(row int, col int) complex128 {
+	if row == (col+1)%(1<<uint(inc.bits)) {
+		return complex(1, 0)
+	}
+	return complex(0, 0)
+}
+
+func (inc *testIncrement) Bits() int {
+	return inc.bits
+}
+
+func (inc *testIncrement) ApplyAmplitudes(amplitudes []complex128, targets []int) []complex128 {
+	inc.applied++
+	return permuteAmplitudes(amplitudes, targets, func(x int) int {
+		return (x + 1) % (1 << uint(inc.bits))
+	})
+}
+
+// A user-defined gate that only provides its matrix
+type testMatrixOnly struct {
+	inc *testIncrement
+}
+
+func (m testMatrixOnly) Get(row int, col int) complex128 {
+	return m.inc.Get(row, col)
+}
+
+func (m testMatrixOnly) Bits() int {
+	return m.inc.Bits()
+}
+
+func TestNewGate(t *testing.T) {
+	inc := &testIncrement{bits: 3}
+	gate := NewGate(inc)
+	if !gate.IsUnitary() {
+		t.Error("User-defined gate is not unitary")
+	}
+	c := NewCircuit(4)
+	c.Apply(gate, 3, 1, 2)
+	qreg := NewQReg(4, 8)
+	c.Run(qreg)
+	if inc.applied != 1 {
+		t.Errorf("User-defined gate applied itself %d times, want 1",
+			inc.applied)
+	}
+	if v := qreg.Measure(); v != 2 {
+		t.Errorf("Bad increment = %d, want 2", v)
+	}
+	// The matrix alone must give the same result
+	verifyFastPath(t, gate, newIrregularQReg(4), []int{3, 1, 2})
+	qreg = newIrregularQReg(4)
+	NewGate(testMatrixOnly{inc}).Apply(qreg, []int{3, 1, 2})
+	want := newIrregularQReg(4)
+	gate.Apply(want, []int{3, 1, 2})
+	for i, amp := range want.amplitudes {
+		if cmplx.Abs(qreg.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				i, qreg.amplitudes[i], amp)
+		}
+	}
+}
+
+// Adjoints and powers describe the same controlled gate, so that they are
+// drawn like it
+func TestAdjointPower_Labels(t *testing.T) {
+	cnot := NewCNOTGate().Adjoint()
+	if cnot.Controls() != 1 || cnot.Name() != "X" {
+		t.Errorf("Bad adjoint of CNOT %s with %d controls", cnot.Name(),
+			cnot.Controls())
+	}
+	phase := NewControlledGate(NewPhaseShiftGate(.5), 1)
+	for _, test := range []struct {
+		gate  *Gate
+		label string
+	}{
+		{phase.Adjoint(), "P(-0.5)"},
+		{phase.Power(3), "P(1.5)"},
+		{phase.Power(-2), "P(-1)"},
+		{NewModExpGate(2, 5, 1, 3).Adjoint(), "ModExp†(2,5)"},
+	} {
+		if label := gateLabel(test.gate); label != test.label {
+			t.Errorf("Bad label %q, want %q", label, test.label)
+		}
+	}
+	if phase.Adjoint().Controls() != 1 || phase.Power(3).Controls() != 1 {
+		t.Error("Controls lost by adjoint or power")
+	}
+	if !phase.Power(3).EqualsUpToPhase(NewControlledGate(
+		NewPhaseShiftGate(1.5), 1)) {
+		t.Error("Bad power of controlled phase shift")
+	}
+}
+
+func TestPermutationGate_Power(t *testing.T) {
+	gate := NewPermutationGate(func(x int) int {
+		return (5*x + 3) % 8
+	}, 3)
+	for _, n := range []int{0, 1, 2, 5, 8, -3} {
+		power := gate.Power(n)
+		if power.permutation == nil {
+			t.Fatalf("Power %d of a permutation gate is not a "+
+				"permutation", n)
+		}
+		// Compare with applying the gate |n| times
+		qreg := newIrregularQReg(3)
+		want := qreg.Copy()
+		step := gate
+		if n < 0 {
+			step = gate.Adjoint()
+		}
+		for i := 0; i < n || i < -n; i++ {
+			step.ApplyReg(want)
+		}
+		power.ApplyReg(qreg)
+		verifySameState(t, qreg, want)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// Dense complex matrices are stored as row-major slices along with their
+// number of rows and columns.
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+const eigenTolerance = 1e-14
+
+// Multiply an (n x m) matrix by an (m x p) matrix
+func matMul(a []complex128, b []complex128, n int, m int, p int) []complex128 {
+	c := make([]complex128, n*p)
+	for i := 0; i < n; i++ {
+		for k := 0; k < m; k++ {
+			aik := a[i*m+k]
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < p; j++ {
+				c[i*p+j] += aik * b[k*p+j]
+			}
+		}
+	}
+	return c
+}
+
+// Get the conjugate transpose of an (n x m) matrix
+func conjTranspose(a []complex128, n int, m int) []complex128 {
+	t := make([]complex128, m*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			t[j*n+i] = cmplx.Conj(a[i*m+j])
+		}
+	}
+	return t
+}
+
+// Diagonalize an (n x n) Hermitian matrix with the cyclic Jacobi method.  The
+// eigenvalues are returned in decreasing order, and column j of the returned
+// unitary is the eigenvector for eigenvalue j.
+func hermitianEigen(m []complex128, n int) ([]float64, []complex128) {
+	a := make([]complex128, n*n)
+	copy(a, m)
+	v := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+	for sweep := 0; sweep < 100; sweep++ {
+		off := float64(0.0)
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += real(a[p*n+q] * cmplx.Conj(a[p*n+q]))
+			}
+		}
+		if off < eigenTolerance*eigenTolerance {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				jacobiRotate(a, v, n, p, q)
+			}
+		}
+	}
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = real(a[i*n+i])
+	}
+	// Sort the eigenpairs by decreasing eigenvalue
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return vals[order[i]] > vals[order[j]]
+	})
+	sorted_vals := make([]float64, n)
+	sorted_vecs := make([]complex128, n*n)
+	for j, k := range order {
+		sorted_vals[j] = vals[k]
+		for i := 0; i < n; i++ {
+			sorted_vecs[i*n+j] = v[i*n+k]
+		}
+	}
+	return sorted_vals, sorted_vecs
+}
+
+// Zero element (p, q) of a Hermitian matrix a by replacing it with J^dagger a J,
+// accumulating the rotation J into v.  J first removes the phase of a[p][q]
+// and then applies the real Jacobi rotation.
+func jacobiRotate(a []complex128, v []complex128, n int, p int, q int) {
+	z := a[p*n+q]
+	r := cmplx.Abs(z)
+	if r < eigenTolerance*eigenTolerance {
+		return
+	}
+	phase := z / complex(r, 0)
+	theta := 0.5 * math.Atan2(2*r, real(a[q*n+q])-real(a[p*n+p]))
+	c := complex(math.Cos(theta), 0)
+	s := complex(math.Sin(theta), 0)
+	// J[p][p] = c, J[p][q] = s, J[q][p] = -s/phase, J[q][q] = c/phase
+	for k := 0; k < n; k++ {
+		akp, akq := a[k*n+p], a[k*n+q]
+		a[k*n+p] = c*akp - s*akq/phase
+		a[k*n+q] = s*akp + c*akq/phase
+		vkp, vkq := v[k*n+p], v[k*n+q]
+		v[k*n+p] = c*vkp - s*vkq/phase
+		v[k*n+q] = s*vkp + c*vkq/phase
+	}
+	for k := 0; k < n; k++ {
+		apk, aqk := a[p*n+k], a[q*n+k]
+		a[p*n+k] = c*apk - s*phase*aqk
+		a[q*n+k] = s*apk + c*phase*aqk
+	}
+	a[p*n+q] = 0
+	a[q*n+p] = 0
+}
+
+// Apply a real function to a Hermitian matrix through its eigenvalues
+func hermitianFunc(m []complex128, n int, f func(float64) float64) []complex128 {
+	vals, vecs := hermitianEigen(m, n)
+	d := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			d[i*n+j] = vecs[i*n+j] * complex(f(vals[j]), 0)
+		}
+	}
+	return matMul(d, conjTranspose(vecs, n, n), n, n, n)
+}
+
+// Compute the thin singular value decomposition m = u diag(s) v^dagger of an
+// (n x p) matrix.  The k = min(n, p) singular values are returned in
+// decreasing order along with u (n x k) and v (p x k).  Singular vectors that
+// belong to zero singular values are left as zero in whichever of u and v is
+// derived from the other.
+func svd(m []complex128, n int, p int) ([]float64, []complex128, []complex128) {
+	if n > p {
+		s, u, v := svd(conjTranspose(m, n, p), p, n)
+		return s, v, u
+	}
+	// Diagonalize m m^dagger = u diag(s^2) u^dagger, then v = m^dagger u / s
+	vals, u := hermitianEigen(matMul(m, conjTranspose(m, n, p), n, p, n), n)
+	s := make([]float64, n)
+	v := make([]complex128, p*n)
+	mt := conjTranspose(m, n, p)
+	for j := 0; j < n; j++ {
+		s[j] = math.Sqrt(math.Max(vals[j], 0))
+		if s[j] < eigenTolerance {
+			continue
+		}
+		for i := 0; i < p; i++ {
+			sum := complex(0, 0)
+			for k := 0; k < n; k++ {
+				sum += mt[i*n+k] * u[k*n+j]
+			}
+			v[i*n+j] = sum / complex(s[j], 0)
+		}
+	}
+	return s, u, v
+}
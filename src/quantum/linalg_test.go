@@ -0,0 +1,74 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestHermitianEigen(t *testing.T) {
+	m := []complex128{
+		2, complex(0, -1), 0,
+		complex(0, 1), 2, 0,
+		0, 0, 5,
+	}
+	vals, vecs := hermitianEigen(m, 3)
+	want := []float64{5, 3, 1}
+	for i, val := range want {
+		if math.Abs(vals[i]-val) > 1e-9 {
+			t.Errorf("Bad eigenvalue %d = %f, want %f", i, vals[i], val)
+		}
+	}
+	// Check m v = lambda v for every eigenpair
+	mv := matMul(m, vecs, 3, 3, 3)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			lv := vecs[i*3+j] * complex(vals[j], 0)
+			if cmplx.Abs(mv[i*3+j]-lv) > 1e-9 {
+				t.Errorf("Bad eigenvector %d at row %d", j, i)
+			}
+		}
+	}
+}
+
+func TestSVD(t *testing.T) {
+	m := []complex128{
+		1, complex(0, 2),
+		0, 1,
+		complex(3, 0), 1,
+	}
+	s, u, v := svd(m, 3, 2)
+	if len(s) != 2 || s[0] < s[1] {
+		t.Fatalf("Bad singular values %v", s)
+	}
+	// Rebuild m from u diag(s) v^dagger
+	us := make([]complex128, 3*2)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			us[i*2+j] = u[i*2+j] * complex(s[j], 0)
+		}
+	}
+	rebuilt := matMul(us, conjTranspose(v, 2, 2), 3, 2, 2)
+	for i, element := range m {
+		if cmplx.Abs(rebuilt[i]-element) > 1e-9 {
+			t.Errorf("Bad reconstruction at %d = %+f, want %+f",
+				i, rebuilt[i], element)
+		}
+	}
+}
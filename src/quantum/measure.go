@@ -0,0 +1,132 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Measure a bit in the basis {basis|0>, basis|1>} given by a one-bit gate.
+// The result is 0 for the first basis state and 1 for the second, and the
+// qubit collapses to the corresponding basis state.
+func (qreg *QReg) BMeasureBasis(index int, basis *Gate) int {
+	if basis.bits() != 1 {
+		panic(fmt.Sprintf("Measurement basis must be a 1-bit gate, "+
+			"not %d bits", basis.bits()))
+	}
+	basis.Adjoint().Apply(qreg, []int{index})
+	b := qreg.BMeasure(index)
+	basis.Apply(qreg, []int{index})
+	return b
+}
+
+// Measure a bit in the X basis {|+>, |->}
+func (qreg *QReg) BMeasureX(index int) int {
+	return qreg.BMeasureBasis(index, NewHadamardGate(1))
+}
+
+// Measure a bit in the Y basis {|+i>, |-i>}
+func (qreg *QReg) BMeasureY(index int) int {
+	h := complex(1/math.Sqrt2, 0)
+	return qreg.BMeasureBasis(index, NewArrayGate([]complex128{
+		h, h,
+		complex(0, 1) * h, complex(0, -1) * h,
+	}))
+}
+
+// Apply a Pauli operator such as "XIZ", where pauli[i] acts on qubits[i], to
+// a copy of the amplitudes
+func applyPauli(amplitudes []complex128, pauli string, qubits []int) []complex128 {
+	if len(pauli) != len(qubits) {
+		panic(fmt.Sprintf("Pauli operator %q does not match %d qubits",
+			pauli, len(qubits)))
+	}
+	// P|s> = i**num_y (-1)**|s & z_mask| |s ^ x_mask>, since Y = iXZ
+	x_mask, z_mask := 0, 0
+	phase := complex(1, 0)
+	for i, p := range pauli {
+		bit := 1 << uint(qubits[i])
+		switch p {
+		case 'I':
+		case 'X':
+			x_mask |= bit
+		case 'Y':
+			x_mask |= bit
+			z_mask |= bit
+			phase *= complex(0, 1)
+		case 'Z':
+			z_mask |= bit
+		default:
+			panic(fmt.Sprintf("%q is not a Pauli operator", p))
+		}
+	}
+	result := make([]complex128, len(amplitudes))
+	for state, amp := range amplitudes {
+		par := 0
+		for anded := state & z_mask; anded > 0; anded >>= 1 {
+			par ^= anded & 1
+		}
+		if par == 1 {
+			result[state^x_mask] = -phase * amp
+		} else {
+			result[state^x_mask] = phase * amp
+		}
+	}
+	return result
+}
+
+// Get the expectation value <psi|P|psi> of a Pauli operator such as "XIZ",
+// where pauli[i] acts on qubits[i]
+func (qreg *QReg) PauliExpectation(pauli string, qubits []int) float64 {
+	qreg.checkQubits(qubits)
+	other := &QReg{width: qreg.width,
+		amplitudes: applyPauli(qreg.amplitudes, pauli, qubits)}
+	return real(qreg.InnerProduct(other))
+}
+
+// Measure a Pauli operator such as "ZZ" without collapsing the quantum state.
+// The result is 0 for the +1 eigenvalue and 1 for the -1 eigenvalue, so
+// measuring "ZZ" gives the parity of two qubits.
+func (qreg *QReg) MeasurePauliPreserve(pauli string, qubits []int) int {
+	if rand.Float64() < (1+qreg.PauliExpectation(pauli, qubits))/2 {
+		return 0
+	}
+	return 1
+}
+
+// Measure a Pauli operator such as "ZZ" (the quantum state collapses onto the
+// matching eigenspace).  The result is 0 for the +1 eigenvalue and 1 for the
+// -1 eigenvalue.
+func (qreg *QReg) MeasurePauli(pauli string, qubits []int) int {
+	qreg.checkQubits(qubits)
+	p_amplitudes := applyPauli(qreg.amplitudes, pauli, qubits)
+	other := &QReg{width: qreg.width, amplitudes: p_amplitudes}
+	prob0 := (1 + real(qreg.InnerProduct(other))) / 2
+	b, sign, prob := 0, complex(1, 0), prob0
+	if rand.Float64() >= prob0 {
+		b, sign, prob = 1, -1, 1-prob0
+	}
+	// Project with (I +- P) / 2 and renormalize
+	amp_factor := complex(.5/math.Sqrt(prob), 0)
+	for state, amp := range qreg.amplitudes {
+		qreg.amplitudes[state] = (amp + sign*p_amplitudes[state]) *
+			amp_factor
+	}
+	return b
+}
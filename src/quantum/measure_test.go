@@ -0,0 +1,119 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBMeasureX(t *testing.T) {
+	qreg := NewQReg(2, 2)
+	Hadamard(qreg, 0)
+	if b := qreg.BMeasureX(0); b != 0 {
+		t.Errorf("Bad X measurement of |+> = %d, want 0", b)
+	}
+	want := qreg.Copy()
+	want.Set(2)
+	Hadamard(want, 0)
+	if !qreg.EqualsUpToPhase(want) {
+		t.Error("X measurement of |+> disturbed the state")
+	}
+
+	qreg = NewQReg(1, 0)
+	b := qreg.BMeasureX(0)
+	if x, _, _ := qreg.BlochVector(0); math.Abs(x-float64(1-2*b)) > 1e-9 {
+		t.Errorf("X measurement of %d left Bloch x = %f", b, x)
+	}
+}
+
+func TestBMeasureY(t *testing.T) {
+	qreg := NewQReg(1, 0)
+	Hadamard(qreg, 0)
+	NewArrayGate([]complex128{1, 0, 0, complex(0, -1)}).ApplyReg(qreg)
+	if b := qreg.BMeasureY(0); b != 1 {
+		t.Errorf("Bad Y measurement of |-i> = %d, want 1", b)
+	}
+	if _, y, _ := qreg.BlochVector(0); math.Abs(y+1) > 1e-9 {
+		t.Errorf("Y measurement of |-i> left Bloch y = %f", y)
+	}
+}
+
+func TestPauliGates(t *testing.T) {
+	// Y = iXZ
+	xz := NewPauliXGate()
+	y := NewPauliYGate()
+	z := NewPauliZGate()
+	for row := 0; row < 2; row++ {
+		for col := 0; col < 2; col++ {
+			v := complex(0, 0)
+			for k := 0; k < 2; k++ {
+				v += xz.get(row, k) * z.get(k, col)
+			}
+			if complex(0, 1)*v != y.get(row, col) {
+				t.Errorf("Y != iXZ at %d, %d", row, col)
+			}
+		}
+	}
+	if !y.Adjoint().EqualsUpToPhase(y) {
+		t.Error("Y is not its own adjoint")
+	}
+}
+
+func TestMeasurePauli_Parity(t *testing.T) {
+	bell := newBellQReg(2, 0, 1)
+	for _, pauli := range []string{"ZZ", "XX"} {
+		qreg := bell.Copy()
+		if b := qreg.MeasurePauli(pauli, []int{0, 1}); b != 0 {
+			t.Errorf("Bad %s measurement of Bell state = %d, want 0",
+				pauli, b)
+		}
+		if !qreg.EqualsUpToPhase(bell) {
+			t.Errorf("%s measurement disturbed a Bell state", pauli)
+		}
+	}
+	if b := bell.MeasurePauli("YY", []int{0, 1}); b != 1 {
+		t.Errorf("Bad YY measurement of Bell state = %d, want 1", b)
+	}
+}
+
+func TestMeasurePauli_Collapse(t *testing.T) {
+	qreg := NewQReg(3, 0)
+	Hadamard(qreg, 0)
+	b := qreg.MeasurePauli("ZIZ", []int{0, 1, 2})
+	want := NewQReg(3, b)
+	if !qreg.EqualsUpToPhase(want) {
+		t.Errorf("ZZ measurement %d did not collapse to |00%d>", b, b)
+	}
+}
+
+func TestMeasurePauli_Syndrome(t *testing.T) {
+	// Encode a|000> + b|111> and flip the middle qubit
+	qreg := NewQReg(3, 0)
+	qreg.amplitudes[0] = complex(.6, 0)
+	qreg.amplitudes[7] = complex(.8, 0)
+	PauliX(qreg, 1)
+	encoded := qreg.Copy()
+	s1 := qreg.MeasurePauli("ZZ", []int{0, 1})
+	s2 := qreg.MeasurePauli("ZZ", []int{1, 2})
+	if s1 != 1 || s2 != 1 {
+		t.Errorf("Bad syndrome %d%d, want 11", s1, s2)
+	}
+	if !qreg.EqualsUpToPhase(encoded) {
+		t.Error("Syndrome measurement disturbed the encoded state")
+	}
+}
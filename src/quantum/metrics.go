@@ -0,0 +1,157 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Pure states
+
+// Get the inner product <qreg|other>
+func (qreg *QReg) InnerProduct(other *QReg) complex128 {
+	if qreg.width != other.width {
+		panic(fmt.Sprintf("Cannot compare QRegs of widths %d and %d",
+			qreg.width, other.width))
+	}
+	sum := complex(0, 0)
+	for i, amp := range qreg.amplitudes {
+		sum += cmplx.Conj(amp) * other.amplitudes[i]
+	}
+	return sum
+}
+
+// Get the fidelity |<qreg|other>|**2 between two pure states
+func (qreg *QReg) Fidelity(other *QReg) float64 {
+	overlap := cmplx.Abs(qreg.InnerProduct(other))
+	return overlap * overlap
+}
+
+// Get the trace distance sqrt(1 - F) between two pure states
+func (qreg *QReg) TraceDistance(other *QReg) float64 {
+	return math.Sqrt(math.Max(0, 1-qreg.Fidelity(other)))
+}
+
+// This tells us whether two registers hold the same state, ignoring the
+// (unobservable) global phase
+func (qreg *QReg) EqualsUpToPhase(other *QReg) bool {
+	return math.Abs(1-qreg.Fidelity(other)) < .0000000001
+}
+
+// Mixed states
+
+func (rho *DensityMatrix) checkWidth(sigma *DensityMatrix) {
+	if rho.width != sigma.width {
+		panic(fmt.Sprintf("Cannot compare states of widths %d and %d",
+			rho.width, sigma.width))
+	}
+}
+
+// Get the fidelity (Tr sqrt(sqrt(rho) sigma sqrt(rho)))**2 between two states
+func (rho *DensityMatrix) Fidelity(sigma *DensityMatrix) float64 {
+	rho.checkWidth(sigma)
+	dim := rho.dim()
+	sqrt := hermitianFunc(rho.elements, dim, func(x float64) float64 {
+		return math.Sqrt(math.Max(x, 0))
+	})
+	m := matMul(matMul(sqrt, sigma.elements, dim, dim, dim), sqrt,
+		dim, dim, dim)
+	sum := float64(0.0)
+	vals, _ := hermitianEigen(m, dim)
+	for _, val := range vals {
+		sum += math.Sqrt(math.Max(val, 0))
+	}
+	return sum * sum
+}
+
+// Get the trace distance ||rho - sigma||_1 / 2 between two states
+func (rho *DensityMatrix) TraceDistance(sigma *DensityMatrix) float64 {
+	rho.checkWidth(sigma)
+	diff := make([]complex128, len(rho.elements))
+	for i, element := range rho.elements {
+		diff[i] = element - sigma.elements[i]
+	}
+	sum := float64(0.0)
+	vals, _ := hermitianEigen(diff, rho.dim())
+	for _, val := range vals {
+		sum += math.Abs(val)
+	}
+	return sum / 2
+}
+
+// This tells us whether two density matrices are equal.  Density matrices
+// carry no global phase, so this is the mixed-state analogue of
+// QReg.EqualsUpToPhase.
+func (rho *DensityMatrix) Equals(sigma *DensityMatrix) bool {
+	if rho.width != sigma.width {
+		return false
+	}
+	for i, element := range rho.elements {
+		if cmplx.Abs(element-sigma.elements[i]) > .0000000001 {
+			return false
+		}
+	}
+	return true
+}
+
+// Gates
+
+// Get |Tr(gate^dagger other)| / 2**bits, the magnitude of the normalized
+// overlap of two gates
+func (gate *Gate) overlap(other *Gate) float64 {
+	if gate.bits() != other.bits() {
+		panic(fmt.Sprintf("Cannot compare gates of %d and %d bits",
+			gate.bits(), other.bits()))
+	}
+	sum := complex(0, 0)
+	for row := 0; row < gate.width(); row++ {
+		for col := 0; col < gate.width(); col++ {
+			sum += cmplx.Conj(gate.get(row, col)) * other.get(row, col)
+		}
+	}
+	return cmplx.Abs(sum) / float64(gate.width())
+}
+
+// Get the process (entanglement) fidelity |Tr(U^dagger V)|**2 / d**2 between
+// two gates
+func (gate *Gate) ProcessFidelity(other *Gate) float64 {
+	overlap := gate.overlap(other)
+	return overlap * overlap
+}
+
+// Get the fidelity between the outputs of two gates, averaged uniformly over
+// all pure input states: (d F_pro + 1) / (d + 1)
+func (gate *Gate) AverageFidelity(other *Gate) float64 {
+	d := float64(gate.width())
+	return (d*gate.ProcessFidelity(other) + 1) / (d + 1)
+}
+
+// Get lower and upper bounds on the diamond-norm distance between two gates.
+// The lower bound is the trace-norm distance between their Choi states and
+// the upper bound is d times that, capped at the maximum distance of 2.
+func (gate *Gate) DiamondDistanceBounds(other *Gate) (float64, float64) {
+	lower := 2 * math.Sqrt(math.Max(0, 1-gate.ProcessFidelity(other)))
+	upper := math.Min(2, float64(gate.width())*lower)
+	return lower, upper
+}
+
+// This tells us whether two gates are equal up to a global phase
+func (gate *Gate) EqualsUpToPhase(other *Gate) bool {
+	return math.Abs(1-gate.overlap(other)) < .0000000001
+}
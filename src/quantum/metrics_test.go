@@ -0,0 +1,94 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestQRegFidelity(t *testing.T) {
+	a := NewQReg(2, 0)
+	HadamardReg(a)
+	b := a.Copy()
+	for i := range b.amplitudes {
+		b.amplitudes[i] *= complex(0, 1)
+	}
+	if !a.EqualsUpToPhase(b) {
+		t.Error("States differing by a global phase reported unequal")
+	}
+	if ip := a.InnerProduct(b); cmplx.Abs(ip-complex(0, 1)) > 1e-9 {
+		t.Errorf("Bad inner product = %+f, want +i", ip)
+	}
+	c := NewQReg(2, 0)
+	if f := a.Fidelity(c); math.Abs(f-.25) > 1e-9 {
+		t.Errorf("Bad fidelity = %f, want 0.25", f)
+	}
+	if d := a.TraceDistance(c); math.Abs(d-math.Sqrt(.75)) > 1e-9 {
+		t.Errorf("Bad trace distance = %f, want %f", d, math.Sqrt(.75))
+	}
+	if a.EqualsUpToPhase(c) {
+		t.Error("Different states reported equal")
+	}
+}
+
+func TestDensityMatrixFidelity(t *testing.T) {
+	bell := newBellQReg(2, 0, 1)
+	mixed := bell.PartialTrace([]int{0})
+	zero := NewDensityMatrix(NewQReg(1, 0))
+	if f := mixed.Fidelity(zero); math.Abs(f-.5) > 1e-9 {
+		t.Errorf("Bad fidelity = %f, want 0.5", f)
+	}
+	if d := mixed.TraceDistance(zero); math.Abs(d-.5) > 1e-9 {
+		t.Errorf("Bad trace distance = %f, want 0.5", d)
+	}
+	// Mixed-state fidelity must agree with the pure-state one
+	a := NewQReg(2, 0)
+	HadamardRange(a, 0, 1)
+	if f := NewDensityMatrix(a).Fidelity(NewDensityMatrix(bell)); math.Abs(f-a.Fidelity(bell)) > 1e-9 {
+		t.Errorf("Bad fidelity of pure states = %f, want %f",
+			f, a.Fidelity(bell))
+	}
+	if !mixed.Equals(bell.PartialTrace([]int{1})) {
+		t.Error("Equal reduced states reported unequal")
+	}
+}
+
+func TestGateFidelity(t *testing.T) {
+	x := NewClassicalGate(func(x int) int { return x ^ 1 }, 1)
+	minus_x := NewRealArrayGate([]float64{0, -1, -1, 0})
+	if !x.EqualsUpToPhase(minus_x) {
+		t.Error("Gates differing by a global phase reported unequal")
+	}
+	if f := x.AverageFidelity(minus_x); math.Abs(f-1) > 1e-9 {
+		t.Errorf("Bad average fidelity = %f, want 1", f)
+	}
+	h := NewHadamardGate(1)
+	// |Tr(X^dagger H)|**2 / 4 = 1/2
+	if f := x.ProcessFidelity(h); math.Abs(f-.5) > 1e-9 {
+		t.Errorf("Bad process fidelity = %f, want 0.5", f)
+	}
+	if f := x.AverageFidelity(h); math.Abs(f-2.0/3) > 1e-9 {
+		t.Errorf("Bad average fidelity = %f, want 2/3", f)
+	}
+	lower, upper := x.DiamondDistanceBounds(h)
+	// The exact diamond distance between X and H is sqrt(2)
+	if lower > math.Sqrt2+1e-9 || upper < math.Sqrt2-1e-9 {
+		t.Errorf("Bad diamond distance bounds [%f, %f]", lower, upper)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// Modular exponentiation |x>|y> -> |x>|y a**x mod N>, the oracle at the heart
+// of period finding.  Starting from y = 1 it computes a**x mod N.
+
+import (
+	"fmt"
+)
+
+// Get a**e mod m
+func PowMod(a int, e int, m int) int {
+	result := 1 % m
+	base := ((a % m) + m) % m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = result * base % m
+		}
+		base = base * base % m
+	}
+	return result
+}
+
+// Make the modular exponentiation as a single permutation gate.  The first
+// inputBits targets hold x and the next outputBits targets hold y.  Values
+// of y of at least modulus are left alone.  The base must be invertible
+// modulo modulus.
+func NewModExpGate(base int, modulus int, inputBits int, outputBits int) *Gate {
+	if modulus > 1<<uint(outputBits) {
+		panic(fmt.Sprintf("Modulus %d does not fit in %d qubits", modulus,
+			outputBits))
+	}
+	modInverse(base, modulus)
+	mask := 1<<uint(inputBits) - 1
+	gate := NewPermutationGate(func(state int) int {
+		x, y := state&mask, state>>uint(inputBits)
+		if y >= modulus {
+			return state
+		}
+		return y*PowMod(base, x, modulus)%modulus<<uint(inputBits) | x
+	},
+		inputBits+outputBits).SetName("ModExp")
+	gate.params = []float64{float64(base), float64(modulus)}
+	return gate
+}
+
+// Append the modular exponentiation y = y base**x mod modulus, decomposed into
+// one controlled modular multiplier per qubit of x.  It needs len(y) + 1
+// scratch qubits and one ancilla.
+func (c *Circuit) ModExp(x []int, y []int, scratch []int, base int, modulus int, ancilla int, controls ...int) {
+	factor := ((base % modulus) + modulus) % modulus
+	for _, qubit := range x {
+		c.ModMultiply(y, scratch, factor, modulus, ancilla,
+			withQubits(controls, qubit)...)
+		factor = factor * factor % modulus
+	}
+}
+
+// Append the modular exponentiation y = y base**x mod modulus on
+// sub-registers, as ModExp
+func (c *Circuit) ModExpSub(x *SubReg, y *SubReg, scratch *SubReg, base int, modulus int, ancilla int, controls ...int) {
+	c.ModExp(x.Qubits(), y.Qubits(), scratch.Qubits(), base, modulus,
+		ancilla, controls...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"testing"
+)
+
+func TestModExpGate(t *testing.T) {
+	gate := NewModExpGate(7, 15, 4, 4)
+	for x := 0; x < 16; x++ {
+		qreg := NewQReg(8, 1<<4|x)
+		gate.ApplyReg(qreg)
+		want := PowMod(7, x, 15)<<4 | x
+		if v := qreg.Measure(); v != want {
+			t.Errorf("Bad 7**%d mod 15 = %d, want %d", x, v>>4, want>>4)
+		}
+	}
+}
+
+func TestModExp(t *testing.T) {
+	base, modulus := 3, 7
+	x, y, scratch, ancilla := []int{0, 1, 2}, []int{3, 4, 5},
+		[]int{6, 7, 8, 9}, 10
+	c := NewCircuit(11)
+	c.ModExp(x, y, scratch, base, modulus, ancilla)
+	gate := NewModExpGate(base, modulus, 3, 3)
+	for v := 0; v < 8; v++ {
+		for w := 1; w < modulus; w++ {
+			in := spreadValue(v, x) | spreadValue(w, y)
+			want := NewQReg(11, in)
+			gate.ApplyRange(want, 0)
+			if out := runBasisState(t, c, in); out != want.Measure() {
+				t.Errorf("Bad %d * %d**%d mod %d = %d, want %d", w,
+					base, v, modulus, subValue(out, y),
+					w*PowMod(base, v, modulus)%modulus)
+			}
+		}
+	}
+}
+
+func TestModExpSub(t *testing.T) {
+	qreg := NewQReg(11, 0)
+	x := qreg.DefineSubRange("x", 0, 3)
+	y := qreg.DefineSubRange("y", 3, 6)
+	scratch := qreg.DefineSubRange("scratch", 6, 10)
+	c := NewCircuit(11)
+	c.AddConstantSub(x, 5)
+	c.AddConstantSub(y, 1)
+	c.ModExpSub(x, y, scratch, 3, 7, 10)
+	c.Run(qreg)
+	if v := y.Measure(); v != PowMod(3, 5, 7) {
+		t.Errorf("Bad 3**5 mod 7 = %d, want %d", v, PowMod(3, 5, 7))
+	}
+}
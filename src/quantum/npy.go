@@ -0,0 +1,268 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// State vectors and gate matrices can be exchanged with NumPy as .npy files
+// holding complex128 arrays.  A QReg is stored as a vector of shape
+// (2**width,) and a gate as a C-ordered matrix of shape (2**bits, 2**bits).
+// As everywhere in this package, qubit i is bit i of an index, so qubit 0 is
+// the least significant bit.  NumPy ignores the comment describing this that
+// follows the header dictionary.
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	npyMagic = "\x93NUMPY"
+
+	// Limits checked before allocating, since the header is untrusted.
+	// No state has more than 2**qregMaxWidth elements, and dense gates are
+	// impractical well before 2**20.
+	npyMaxHeaderLen = 1 << 16
+	npyMaxStateSize = 1 << qregMaxWidth
+	npyMaxGateSize  = 1 << 20
+
+	// The array data is read this many elements at a time, so that memory
+	// is only allocated for data that is actually present.
+	npyChunkSize = 1 << 16
+)
+
+var (
+	npyDescrPattern   = regexp.MustCompile(`'descr':\s*'([<>|=]?)c16'`)
+	npyFortranPattern = regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+	npyShapePattern   = regexp.MustCompile(`'shape':\s*\(([^)]*)\)`)
+)
+
+// Write a C-ordered little-endian complex128 array in .npy format 1.0
+func writeNpy(w io.Writer, shape []int, data []complex128, comment string) error {
+	dims := make([]string, len(shape))
+	for i, dim := range shape {
+		dims[i] = strconv.Itoa(dim)
+	}
+	shape_str := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shape_str += ","
+	}
+	header := fmt.Sprintf("{'descr': '<c16', 'fortran_order': False, "+
+		"'shape': (%s), } # %s", shape_str, comment)
+	// Pad with spaces so that the data starts on a 64-byte boundary,
+	// counting the magic string, version and header length.
+	prefix := len(npyMagic) + 4
+	padding := 63 - (prefix+len(header))%64
+	header += strings.Repeat(" ", padding) + "\n"
+	if len(header) > math.MaxUint16 {
+		return errors.New("quantum: .npy header is too long")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	for _, v := range data {
+		binary.Write(&buf, binary.LittleEndian, [2]float64{real(v), imag(v)})
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Read a complex128 array of at most maxSize elements in .npy format,
+// returning its shape and its elements in C order
+func readNpy(r io.Reader, maxSize int) ([]int, []complex128, error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, nil, err
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, nil, errors.New("quantum: not a .npy file")
+	}
+	var header_len int
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var l uint16
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return nil, nil, err
+		}
+		header_len = int(l)
+	case 2, 3:
+		var l uint32
+		if err := binary.Read(r, binary.LittleEndian, &l); err != nil {
+			return nil, nil, err
+		}
+		header_len = int(l)
+	default:
+		return nil, nil, fmt.Errorf("quantum: unsupported .npy version "+
+			"%d", major)
+	}
+	if header_len > npyMaxHeaderLen {
+		return nil, nil, fmt.Errorf("quantum: .npy header of %d bytes "+
+			"is too long", header_len)
+	}
+	header := make([]byte, header_len)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, err
+	}
+
+	descr := npyDescrPattern.FindSubmatch(header)
+	if descr == nil {
+		return nil, nil, errors.New("quantum: .npy array is not complex128")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if string(descr[1]) == ">" {
+		order = binary.BigEndian
+	}
+	fortran := npyFortranPattern.FindSubmatch(header)
+	if fortran == nil {
+		return nil, nil, errors.New("quantum: .npy header has no " +
+			"fortran_order")
+	}
+	shape_match := npyShapePattern.FindSubmatch(header)
+	if shape_match == nil {
+		return nil, nil, errors.New("quantum: .npy header has no shape")
+	}
+	shape := []int{}
+	size := 1
+	for _, dim := range strings.Split(string(shape_match[1]), ",") {
+		dim = strings.TrimSpace(dim)
+		if dim == "" {
+			continue
+		}
+		n, err := strconv.Atoi(dim)
+		if err != nil || n < 0 {
+			return nil, nil, fmt.Errorf("quantum: bad .npy shape "+
+				"(%s)", shape_match[1])
+		}
+		if n > 0 && size > maxSize/n {
+			return nil, nil, fmt.Errorf("quantum: .npy shape "+
+				"(%s) is too large", shape_match[1])
+		}
+		shape = append(shape, n)
+		size *= n
+	}
+
+	chunk_size := npyChunkSize
+	if size < chunk_size {
+		chunk_size = size
+	}
+	data := make([]complex128, 0, chunk_size)
+	raw := make([]byte, 16*chunk_size)
+	for len(data) < size {
+		chunk := raw
+		if remaining := size - len(data); remaining < chunk_size {
+			chunk = raw[:16*remaining]
+		}
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return nil, nil, err
+		}
+		for i := 0; i < len(chunk); i += 16 {
+			re := math.Float64frombits(order.Uint64(chunk[i:]))
+			im := math.Float64frombits(order.Uint64(chunk[i+8:]))
+			data = append(data, complex(re, im))
+		}
+	}
+	if string(fortran[1]) == "True" && len(shape) == 2 {
+		// Column-major, so element (i, j) is at j*rows + i
+		c_data := make([]complex128, size)
+		for i := 0; i < shape[0]; i++ {
+			for j := 0; j < shape[1]; j++ {
+				c_data[i*shape[1]+j] = data[j*shape[0]+i]
+			}
+		}
+		data = c_data
+	}
+	return shape, data, nil
+}
+
+// Get log2(n) if n is a power of two
+func log2Exact(n int) (int, bool) {
+	bits := 0
+	for 1<<uint(bits) < n {
+		bits++
+	}
+	return bits, n == 1<<uint(bits)
+}
+
+// Write the register's amplitudes as a .npy vector
+func (qreg *QReg) WriteNpy(w io.Writer) error {
+	comment := fmt.Sprintf("quantum QReg of width %d; qubit i is bit i "+
+		"of the index", qreg.width)
+	return writeNpy(w, []int{len(qreg.amplitudes)}, qreg.amplitudes, comment)
+}
+
+// Read a register from a .npy vector of normalized amplitudes
+func ReadQRegNpy(r io.Reader) (*QReg, error) {
+	shape, data, err := readNpy(r, npyMaxStateSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 1 {
+		return nil, fmt.Errorf("quantum: expected a vector, got shape %v",
+			shape)
+	}
+	width, ok := log2Exact(shape[0])
+	if !ok {
+		return nil, fmt.Errorf("quantum: %d amplitudes is not a power "+
+			"of two", shape[0])
+	}
+	if err := checkDecodedState(width, data); err != nil {
+		return nil, err
+	}
+	return &QReg{width: width, amplitudes: data}, nil
+}
+
+// Write the gate's matrix as a .npy array
+func (gate *Gate) WriteNpy(w io.Writer) error {
+	width := gate.width()
+	comment := fmt.Sprintf("quantum Gate on %d qubits; target i is bit i "+
+		"of the row and column indices", gate.bits())
+	return writeNpy(w, []int{width, width}, gate.elements(), comment)
+}
+
+// Read a gate from a .npy array holding a unitary matrix
+func ReadGateNpy(r io.Reader) (*Gate, error) {
+	shape, data, err := readNpy(r, npyMaxGateSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 2 || shape[0] != shape[1] {
+		return nil, fmt.Errorf("quantum: expected a square matrix, "+
+			"got shape %v", shape)
+	}
+	bits, ok := log2Exact(shape[0])
+	if !ok {
+		return nil, fmt.Errorf("quantum: matrix width %d is not a "+
+			"power of two", shape[0])
+	}
+	width := shape[0]
+	gate := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		return data[row*width+col]
+	},
+		bits)
+	if !gate.IsUnitary() {
+		return nil, errors.New("quantum: matrix is not unitary")
+	}
+	return gate, nil
+}
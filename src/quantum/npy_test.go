@@ -0,0 +1,139 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/cmplx"
+	"strings"
+	"testing"
+)
+
+func TestQRegNpy(t *testing.T) {
+	qreg := newTestQReg()
+	var buf bytes.Buffer
+	if err := qreg.WriteNpy(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if header_end := 10 + int(data[8]) + int(data[9])<<8; header_end%64 != 0 {
+		t.Errorf("Array data starts at unaligned offset %d", header_end)
+	}
+	if !strings.Contains(string(data), "'shape': (4,)") {
+		t.Errorf("Bad .npy header %q", data[:64])
+	}
+	decoded, err := ReadQRegNpy(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifySameState(t, decoded, qreg)
+}
+
+func TestGateNpy(t *testing.T) {
+	gate := NewArrayGate([]complex128{
+		1, 0, 0, 0,
+		0, 0, complex(0, 1), 0,
+		0, complex(0, 1), 0, 0,
+		0, 0, 0, -1,
+	})
+	var buf bytes.Buffer
+	if err := gate.WriteNpy(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ReadGateNpy(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			if decoded.get(row, col) != gate.get(row, col) {
+				t.Errorf("Bad element %d, %d = %+f, want %+f",
+					row, col, decoded.get(row, col),
+					gate.get(row, col))
+			}
+		}
+	}
+}
+
+func TestGateNpy_FortranOrder(t *testing.T) {
+	header := "{'descr': '<c16', 'fortran_order': True, 'shape': (2, 2), }"
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY\x01\x00")
+	buf.Write([]byte{byte(len(header) + 1), 0})
+	buf.WriteString(header + "\n")
+	// Column-major [[0, i], [1, 0]]
+	binary.Write(&buf, binary.LittleEndian,
+		[]float64{0, 0, 1, 0, 0, 1, 0, 0})
+	gate, err := ReadGateNpy(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gate.get(0, 1) != complex(0, 1) || gate.get(1, 0) != 1 {
+		t.Errorf("Fortran-ordered matrix was not transposed")
+	}
+}
+
+func TestReadGateNpy_NotUnitary(t *testing.T) {
+	var buf bytes.Buffer
+	writeNpy(&buf, []int{2, 2}, []complex128{1, 1, 1, 1}, "")
+	if _, err := ReadGateNpy(&buf); err == nil {
+		t.Error("Read a non-unitary gate")
+	}
+}
+
+func TestReadQRegNpy_NotFinite(t *testing.T) {
+	var buf bytes.Buffer
+	writeNpy(&buf, []int{2}, []complex128{1, cmplx.NaN()}, "")
+	if _, err := ReadQRegNpy(&buf); err == nil {
+		t.Error("Read a QReg with a NaN amplitude")
+	}
+}
+
+// Helper function for testing. Makes a .npy header for the given shape with
+// no array data after it.
+func newNpyHeader(shape string) string {
+	header := "{'descr': '<c16', 'fortran_order': False, " +
+		"'shape': (" + shape + "), }\n"
+	return "\x93NUMPY\x01\x00" + string([]byte{byte(len(header)), 0}) +
+		header
+}
+
+func TestReadQRegNpy_HugeShape(t *testing.T) {
+	for _, shape := range []string{"99999999999,", "4294967296, 4294967296"} {
+		if _, _, err := readNpy(strings.NewReader(newNpyHeader(shape)),
+			npyMaxStateSize); err == nil ||
+			!strings.Contains(err.Error(), "too large") {
+			t.Errorf("Read shape (%s) with error %v", shape, err)
+		}
+	}
+}
+
+func TestReadNpy_Truncated(t *testing.T) {
+	// The largest state allowed, with no data: this must fail without
+	// allocating 16 GiB first
+	if _, err := ReadQRegNpy(strings.NewReader(
+		newNpyHeader("1073741824,"))); err == nil {
+		t.Error("Read a QReg with no amplitudes")
+	}
+	// A gate matrix is capped much lower than a state
+	if _, err := ReadGateNpy(strings.NewReader(
+		newNpyHeader("4096, 4096"))); err == nil ||
+		!strings.Contains(err.Error(), "too large") {
+		t.Errorf("Read an oversized gate with error %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// Oracles built from classical Boolean functions.  Both kinds apply by
+// moving or scaling amplitudes directly rather than through their matrices.
+
+// Make the oracle |x, y> -> |x, y ^ f(x)>, where x is held by the first
+// inputBits targets and y by the last target
+func NewBitOracle(f func(x int) bool, inputBits int) *Gate {
+	mask := 1<<uint(inputBits) - 1
+	flip := func(x int) int {
+		if f(x & mask) {
+			return x ^ (mask + 1)
+		}
+		return x
+	}
+	return NewPermutationGate(flip, inputBits+1).SetName("Uf")
+}
+
+// Make the oracle |x> -> (-1)**f(x) |x> on bits targets
+func NewPhaseOracle(f func(x int) bool, bits int) *Gate {
+	diagonal := make([]complex128, 1<<uint(bits))
+	for x := range diagonal {
+		diagonal[x] = complex(1, 0)
+		if f(x) {
+			diagonal[x] = complex(-1, 0)
+		}
+	}
+	return NewDiagonalGate(diagonal).SetName("Zf")
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Helper function for testing. Applies a gate to a register both through its
+// fast path and through its matrix and checks that the results agree.
+func verifyFastPath(t *testing.T, gate *Gate, qreg *QReg, targets []int) {
+	fast := qreg.Copy()
+	gate.Apply(fast, targets)
+	dense := *gate
+	dense.apply = nil
+	dense.applyInPlace = nil
+	slow := qreg.Copy()
+	dense.Apply(slow, targets)
+	for i, amp := range slow.amplitudes {
+		if cmplx.Abs(fast.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Bad %s amplitude for state %d = %+f, want %+f",
+				gate.Name(), i, fast.amplitudes[i], amp)
+		}
+	}
+}
+
+func TestBitOracle(t *testing.T) {
+	oracle := NewBitOracle(func(x int) bool {
+		return x == 2 || x == 3
+	}, 2)
+	if !oracle.IsUnitary() {
+		t.Error("Bit oracle is not unitary")
+	}
+	// Input on qubits 3 and 1, output on qubit 0
+	qreg := NewQReg(4, 2)
+	oracle.Apply(qreg, []int{3, 1, 0})
+	if qreg.BMeasure(0) != 1 {
+		t.Error("Bit oracle did not flip the output for x = 2")
+	}
+	verifyFastPath(t, oracle, newIrregularQReg(4), []int{3, 1, 0})
+}
+
+func TestPhaseOracle(t *testing.T) {
+	oracle := NewPhaseOracle(func(x int) bool {
+		return x == 1
+	}, 2)
+	if !oracle.IsUnitary() {
+		t.Error("Phase oracle is not unitary")
+	}
+	qreg := NewQReg(3, 0)
+	HadamardReg(qreg)
+	oracle.Apply(qreg, []int{2, 0})
+	for state, amp := range qreg.amplitudes {
+		want := complex(1/(2*math.Sqrt2), 0)
+		if state == 4 || state == 6 {
+			want = -want
+		}
+		if cmplx.Abs(amp-want) > 1e-9 {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				state, amp, want)
+		}
+	}
+	verifyFastPath(t, oracle, newIrregularQReg(3), []int{2, 0})
+}
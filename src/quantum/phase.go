@@ -0,0 +1,106 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+	"math"
+)
+
+// Build the phase estimation circuit for a gate u.  Qubits 0 to precision-1
+// count and the following u.Bits() qubits hold the eigenstate, which prep
+// prepares from |0...0> (a nil prep leaves them as |0...0>).  Counting qubit k
+// controls u**(2**k), so measuring the counting qubits as an integer j
+// estimates the eigenphase as j / 2**precision.
+func NewPhaseEstimationCircuit(u *Gate, prep *Gate, precision int) *Circuit {
+	if prep != nil && prep.bits() != u.bits() {
+		panic(fmt.Sprintf("%d-bit preparation for %d-bit gate",
+			prep.bits(), u.bits()))
+	}
+	c := NewCircuit(precision + u.bits())
+	counting := qubitRange(0, precision)
+	targets := qubitRange(precision, precision+u.bits())
+	if prep != nil {
+		c.Apply(prep, targets...)
+	}
+	h := NewHadamardGate(1)
+	for _, qubit := range counting {
+		c.Apply(h, qubit)
+	}
+	power := u
+	for k, qubit := range counting {
+		if k > 0 {
+			power = power.Power(2)
+			power.SetName(fmt.Sprintf("%s^%d", u.Name(), 1<<uint(k)))
+		}
+		c.Apply(NewControlledGate(power, 1), append([]int{qubit}, targets...)...)
+	}
+	c.Apply(NewInverseQFTGate(precision), counting...)
+	return c
+}
+
+// Estimate the eigenphase of a gate u for the eigenstate prepared by prep to
+// the given number of bits.  Element j of the result is the probability of
+// measuring the phase j / 2**precision.
+func PhaseEstimation(u *Gate, prep *Gate, precision int) []float64 {
+	c := NewPhaseEstimationCircuit(u, prep, precision)
+	qreg := NewQReg(c.Width(), 0)
+	c.Run(qreg)
+	counting := qubitRange(0, precision)
+	probs := make([]float64, 1<<uint(precision))
+	for state, _ := range qreg.amplitudes {
+		probs[subValue(state, counting)] += qreg.StateProb(state)
+	}
+	return probs
+}
+
+// Estimate the eigenphase of a gate u for the eigenstate prepared by prep to
+// the given number of bits, using a single counting qubit that is measured
+// and reused for each bit, least significant first.  The result is one
+// sample of the estimated phase, in [0, 1).
+func IterativePhaseEstimation(u *Gate, prep *Gate, precision int) float64 {
+	if prep != nil && prep.bits() != u.bits() {
+		panic(fmt.Sprintf("%d-bit preparation for %d-bit gate",
+			prep.bits(), u.bits()))
+	}
+	// Qubit 0 is the counting qubit and the rest hold the eigenstate.
+	qreg := NewQReg(u.bits()+1, 0)
+	targets := qubitRange(0, u.bits()+1)
+	if prep != nil {
+		prep.Apply(qreg, targets[1:])
+	}
+	powers := make([]*Gate, precision)
+	powers[0] = u
+	for k := 1; k < precision; k++ {
+		powers[k] = powers[k-1].Power(2)
+	}
+	h := NewHadamardGate(1)
+	// After round r, bits 0 to r of j are known, where phase = j / 2**precision
+	j := 0
+	for r := 0; r < precision; r++ {
+		qreg.Reset(0)
+		h.Apply(qreg, targets[:1])
+		// The counting qubit picks up e**(2 pi i 0.x_r ... x_0), with
+		// the digits after x_r already known from earlier rounds.
+		NewControlledGate(powers[precision-1-r], 1).Apply(qreg, targets)
+		angle := -2 * math.Pi * float64(j) / float64(int(1)<<uint(r+1))
+		NewPhaseShiftGate(angle).Apply(qreg, targets[:1])
+		h.Apply(qreg, targets[:1])
+		j |= qreg.BMeasure(0) << uint(r)
+	}
+	return float64(j) / float64(int(1)<<uint(precision))
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPhaseEstimation_Exact(t *testing.T) {
+	u := NewPhaseShiftGate(2 * math.Pi * 5 / 16)
+	probs := PhaseEstimation(u, NewPauliXGate(), 4)
+	if len(probs) != 16 {
+		t.Fatalf("Bad distribution length %d, want 16", len(probs))
+	}
+	if math.Abs(probs[5]-1) > 1e-9 {
+		t.Errorf("Bad probability of phase 5/16 = %f, want 1", probs[5])
+	}
+	// |0> has eigenphase 0
+	probs = PhaseEstimation(u, nil, 4)
+	if math.Abs(probs[0]-1) > 1e-9 {
+		t.Errorf("Bad probability of phase 0 = %f, want 1", probs[0])
+	}
+}
+
+func TestPhaseEstimation_Inexact(t *testing.T) {
+	// A phase of 1/3 is closest to 5/16 and next closest to 6/16
+	u := NewPhaseShiftGate(2 * math.Pi / 3)
+	probs := PhaseEstimation(u, NewPauliXGate(), 4)
+	best := 0
+	total := float64(0.0)
+	for j, prob := range probs {
+		total += prob
+		if prob > probs[best] {
+			best = j
+		}
+	}
+	if best != 5 || probs[5] < 4/(math.Pi*math.Pi) {
+		t.Errorf("Bad phase distribution %v", probs)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Distribution sums to %f, want 1", total)
+	}
+}
+
+func TestIterativePhaseEstimation(t *testing.T) {
+	// Controlled-Z has eigenphase 1/2 on |11>
+	u := NewControlledGate(NewPauliZGate(), 1)
+	prep := NewRealArrayGate([]float64{
+		0, 0, 0, 1,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		1, 0, 0, 0,
+	})
+	if phase := IterativePhaseEstimation(u, prep, 3); phase != .5 {
+		t.Errorf("Bad phase estimate %f, want 0.5", phase)
+	}
+	u = NewPhaseShiftGate(2 * math.Pi * 11 / 32)
+	for i := 0; i < 10; i++ {
+		phase := IterativePhaseEstimation(u, NewPauliXGate(), 5)
+		if phase != 11.0/32 {
+			t.Fatalf("Bad phase estimate %f, want 11/32", phase)
+		}
+	}
+}
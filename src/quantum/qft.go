@@ -0,0 +1,161 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+// The quantum Fourier transform on n qubits maps |x> to
+// sum_y e**(2 pi i x y / 2**n) |y> / sqrt(2**n), where, as everywhere in this
+// package, target i is bit i of x and y.
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Transform a vector whose length is a power of 2 in place with the radix-2
+// Cooley-Tukey FFT, computing sum_x e**(sign 2 pi i x y / n) v[x] for each y
+func fft(v []complex128, sign float64) {
+	n := len(v)
+	// Put the elements in bit-reversed order
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			v[i], v[j] = v[j], v[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, sign*2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := v[start+k], v[start+k+size/2]*w
+				v[start+k], v[start+k+size/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
+
+// Apply the QFT, or its inverse for a sign of -1, to the given targets of a
+// register's amplitudes by running an FFT over each subarray of amplitudes
+// that share the values of the other qubits
+func fourierAmplitudes(amplitudes []complex128, targets []int, sign float64) []complex128 {
+	width := 1 << uint(len(targets))
+	spread := make([]int, width)
+	for x := range spread {
+		spread[x] = spreadValue(x, targets)
+	}
+	mask := spread[width-1]
+	norm := complex(1/math.Sqrt(float64(width)), 0)
+	new_states := make([]complex128, len(amplitudes))
+	sub := make([]complex128, width)
+	for base := range amplitudes {
+		if base&mask != 0 {
+			continue
+		}
+		for x := range sub {
+			sub[x] = amplitudes[base|spread[x]]
+		}
+		fft(sub, sign)
+		for y, amp := range sub {
+			new_states[base|spread[y]] = amp * norm
+		}
+	}
+	return new_states
+}
+
+// Make the QFT as a single gate, applied with an FFT
+func NewQFTGate(bits int) *Gate {
+	width := 1 << uint(bits)
+	norm := complex(1/math.Sqrt(float64(width)), 0)
+	gate := NewFuncGateNoCheck(func(row int, col int) complex128 {
+		angle := 2 * math.Pi * float64(row*col%width) / float64(width)
+		return cmplx.Exp(complex(0, angle)) * norm
+	},
+		bits).SetName("QFT")
+	gate.apply = func(amplitudes []complex128, targets []int) []complex128 {
+		return fourierAmplitudes(amplitudes, targets, 1)
+	}
+	return gate
+}
+
+// Make the inverse QFT as a single gate, applied with an FFT
+func NewInverseQFTGate(bits int) *Gate {
+	gate := NewQFTGate(bits).Adjoint()
+	gate.apply = func(amplitudes []complex128, targets []int) []complex128 {
+		return fourierAmplitudes(amplitudes, targets, -1)
+	}
+	return gate
+}
+
+// Append the QFT on the given qubits, decomposed into Hadamard, controlled
+// phase shift and swap gates
+func (c *Circuit) QFT(qubits ...int) {
+	n := len(qubits)
+	h := NewHadamardGate(1)
+	// Handle the most significant qubit first
+	for i := n - 1; i >= 0; i-- {
+		c.Apply(h, qubits[i])
+		for j := i - 1; j >= 0; j-- {
+			angle := 2 * math.Pi / float64(int(1)<<uint(i-j+1))
+			c.Apply(NewControlledGate(NewPhaseShiftGate(angle), 1),
+				qubits[j], qubits[i])
+		}
+	}
+	for i := 0; i < n/2; i++ {
+		c.Apply(NewSwapGate(), qubits[i], qubits[n-1-i])
+	}
+}
+
+// Append the inverse QFT on the given qubits, decomposed in the same way as
+// Circuit.QFT
+func (c *Circuit) InverseQFT(qubits ...int) {
+	n := len(qubits)
+	for i := n/2 - 1; i >= 0; i-- {
+		c.Apply(NewSwapGate(), qubits[i], qubits[n-1-i])
+	}
+	h := NewHadamardGate(1)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			angle := -2 * math.Pi / float64(int(1)<<uint(i-j+1))
+			c.Apply(NewControlledGate(NewPhaseShiftGate(angle), 1),
+				qubits[j], qubits[i])
+		}
+		c.Apply(h, qubits[i])
+	}
+}
+
+func QFTRange(qreg *QReg, target_range_start int, target_range_end int) {
+	gate := NewQFTGate(target_range_end - target_range_start)
+	gate.ApplyRange(qreg, target_range_start)
+}
+
+func QFTReg(qreg *QReg) {
+	QFTRange(qreg, 0, qreg.width)
+}
+
+func InverseQFTRange(qreg *QReg, target_range_start int, target_range_end int) {
+	gate := NewInverseQFTGate(target_range_end - target_range_start)
+	gate.ApplyRange(qreg, target_range_start)
+}
+
+func InverseQFTReg(qreg *QReg) {
+	InverseQFTRange(qreg, 0, qreg.width)
+}
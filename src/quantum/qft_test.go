@@ -0,0 +1,94 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// Helper function for testing. Returns a register of the given width in a
+// state with distinct, irregular amplitudes.
+func newIrregularQReg(width int) *QReg {
+	qreg := NewQReg(width, 0)
+	norm := float64(0.0)
+	for i := range qreg.amplitudes {
+		qreg.amplitudes[i] = complex(float64(i%5)+1, float64(i%3)-1)
+		norm += real(qreg.amplitudes[i] * cmplx.Conj(qreg.amplitudes[i]))
+	}
+	for i := range qreg.amplitudes {
+		qreg.amplitudes[i] /= complex(math.Sqrt(norm), 0)
+	}
+	return qreg
+}
+
+func TestQFTRange(t *testing.T) {
+	qreg := newIrregularQReg(5)
+	// The FFT must agree with the gate-level QFT
+	want := qreg.Copy()
+	c := NewCircuit(5)
+	c.QFT(1, 2, 3)
+	c.Run(want)
+	QFTRange(qreg, 1, 4)
+	for i, amp := range want.amplitudes {
+		if cmplx.Abs(qreg.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				i, qreg.amplitudes[i], amp)
+		}
+	}
+	InverseQFTRange(qreg, 1, 4)
+	for i, amp := range newIrregularQReg(5).amplitudes {
+		if cmplx.Abs(qreg.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Inverse QFT gave amplitude %+f for state %d, "+
+				"want %+f", qreg.amplitudes[i], i, amp)
+		}
+	}
+}
+
+func TestQFTGate(t *testing.T) {
+	if !NewQFTGate(3).IsUnitary() {
+		t.Error("QFT is not unitary")
+	}
+	// The QFT of |1> has amplitudes e**(2 pi i y / 4) / 2
+	qreg := NewQReg(2, 1)
+	QFTReg(qreg)
+	want := []complex128{.5, complex(0, .5), -.5, complex(0, -.5)}
+	for i, amp := range want {
+		if cmplx.Abs(qreg.amplitudes[i]-amp) > 1e-9 {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				i, qreg.amplitudes[i], amp)
+		}
+	}
+}
+
+func TestQFTGate_FastPath(t *testing.T) {
+	verifyFastPath(t, NewQFTGate(3), newIrregularQReg(5), []int{4, 0, 2})
+	verifyFastPath(t, NewInverseQFTGate(3), newIrregularQReg(5),
+		[]int{4, 0, 2})
+}
+
+func TestQFTReg_Large(t *testing.T) {
+	bits := 14
+	qreg := NewQReg(bits, 0)
+	HadamardReg(qreg)
+	// The QFT of the uniform superposition is |0>
+	QFTReg(qreg)
+	if p := qreg.StateProb(0); math.Abs(p-1) > 1e-9 {
+		t.Errorf("Bad probability of |0> = %f, want 1", p)
+	}
+}
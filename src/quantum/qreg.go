@@ -37,11 +37,14 @@ type QReg struct {
         // The complex amplitudes for each of the standard basis states.
         // There are math.Pow(2,width) of these.
 	amplitudes []complex128
+
+	// Named sub-registers, mapping each name to the qubits it covers.
+	subs map[string]*SubReg
 }
 
 // Constructor for a QReg
 func NewQReg(width int, values ...int) *QReg {
-	qreg := &QReg{width, nil}
+	qreg := &QReg{width: width}
 	qreg.Set(values...)
 	return qreg
 }
@@ -53,11 +56,47 @@ func (qreg *QReg) Width() int {
 
 // Copy a quantum register
 func (qreg *QReg) Copy() *QReg {
-	new_qreg := &QReg{qreg.width, make([]complex128, len(qreg.amplitudes))}
+	new_qreg := &QReg{width: qreg.width,
+		amplitudes: make([]complex128, len(qreg.amplitudes))}
 	copy(new_qreg.amplitudes, qreg.amplitudes)
+	for name, sub := range qreg.subs {
+		new_qreg.DefineSub(name, sub.qubits...)
+	}
 	return new_qreg
 }
 
+// Compute the tensor product of two quantum registers.  The qubits of qreg
+// occupy the low indices of the result and those of other follow them.  Named
+// sub-registers of both are carried over, shifting the ones from other, so
+// the two registers must not define sub-registers with the same name.
+func (qreg *QReg) Tensor(other *QReg) *QReg {
+	for name := range other.subs {
+		if _, ok := qreg.subs[name]; ok {
+			panic(fmt.Sprintf("Both registers define a sub-register "+
+				"named %q", name))
+		}
+	}
+	result := &QReg{width: qreg.width + other.width,
+		amplitudes: make([]complex128, len(qreg.amplitudes)*len(other.amplitudes))}
+	for j, b := range other.amplitudes {
+		offset := j << uint(qreg.width)
+		for i, a := range qreg.amplitudes {
+			result.amplitudes[offset|i] = a * b
+		}
+	}
+	for name, sub := range qreg.subs {
+		result.DefineSub(name, sub.qubits...)
+	}
+	for name, sub := range other.subs {
+		shifted := make([]int, len(sub.qubits))
+		for i, qubit := range sub.qubits {
+			shifted[i] = qubit + qreg.width
+		}
+		result.DefineSub(name, shifted...)
+	}
+	return result
+}
+
 // Get the probability of observing a state
 func (qreg *QReg) StateProb(state int) float64 {
 	return cmplx.Abs(qreg.amplitudes[state] * qreg.amplitudes[state])
@@ -162,6 +201,75 @@ func (qreg *QReg) BMeasure(index int) int {
 	return b
 }
 
+// Reset a bit to 0.  Unlike BSet, which post-selects on the new value, this
+// measures the bit and flips it if it was 1, so the rest of the register
+// collapses as it would for a measurement.
+func (qreg *QReg) Reset(index int) {
+	if qreg.BMeasure(index) == 1 {
+		bit := 1 << uint(index)
+		// Iterate through all the amplitudes where this bit is 1
+		for state := 0 | bit; state < len(qreg.amplitudes); state = (state + 1) | bit {
+			qreg.amplitudes[state-bit] = qreg.amplitudes[state]
+			qreg.amplitudes[state] = complex(0, 0)
+		}
+	}
+}
+
+// Add qubits, each in the state |0>, to the register.  The new qubits follow
+// the existing ones; the index of the first one is returned.
+func (qreg *QReg) Alloc(width int) int {
+	if width < 0 {
+		panic(fmt.Sprintf("Cannot allocate %d qubits", width))
+	}
+	first := qreg.width
+	amplitudes := make([]complex128, len(qreg.amplitudes)<<uint(width))
+	copy(amplitudes, qreg.amplitudes)
+	qreg.width += width
+	qreg.amplitudes = amplitudes
+	return first
+}
+
+// Remove a qubit, which must be in a basis state, from the register.  Qubits
+// with higher indices move down by one, as do those of named sub-registers,
+// and the released qubit is dropped from any sub-register containing it.
+// SubRegs already retrieved follow these changes; a sub-register left with no
+// qubits is removed and can no longer be used.
+func (qreg *QReg) Release(index int) {
+	if index < 0 || index >= qreg.width {
+		panic(fmt.Sprintf("%d is not a valid qubit", index))
+	}
+	value := 0
+	if qreg.BProb(index, 0) < .0000000001 {
+		value = 1
+	} else if qreg.BProb(index, 1) >= .0000000001 {
+		panic(fmt.Sprintf("Qubit %d is not in a basis state", index))
+	}
+	low := (1 << uint(index)) - 1
+	amplitudes := make([]complex128, len(qreg.amplitudes)>>1)
+	for state := range amplitudes {
+		// Insert the released bit back into the state
+		old_state := (state&^low)<<1 | value<<uint(index) | state&low
+		amplitudes[state] = qreg.amplitudes[old_state]
+	}
+	qreg.width--
+	qreg.amplitudes = amplitudes
+	for name, sub := range qreg.subs {
+		kept := make([]int, 0, len(sub.qubits))
+		for _, qubit := range sub.qubits {
+			if qubit > index {
+				kept = append(kept, qubit-1)
+			} else if qubit < index {
+				kept = append(kept, qubit)
+			}
+		}
+		sub.qubits = kept
+		if len(kept) == 0 {
+			sub.released = true
+			delete(qreg.subs, name)
+		}
+	}
+}
+
 // Measure a register without collapsing its quantum state
 func (qreg *QReg) MeasurePreserve() int {
 	r := rand.Float64()
@@ -191,6 +299,105 @@ func (qreg *QReg) Measure() int {
 	return value
 }
 
+// Panic unless qubits is a list of distinct qubits of the register
+func (qreg *QReg) checkQubits(qubits []int) {
+	seen := make(map[int]bool)
+	for _, qubit := range qubits {
+		if qubit < 0 || qubit >= qreg.width {
+			panic(fmt.Sprintf("%d is not a valid qubit", qubit))
+		}
+		if seen[qubit] {
+			panic(fmt.Sprintf("Qubit %d appears twice", qubit))
+		}
+		seen[qubit] = true
+	}
+}
+
+// Get the qubits from start up to, but not including, end
+func qubitRange(start int, end int) []int {
+	qubits := make([]int, end-start)
+	for i := range qubits {
+		qubits[i] = start + i
+	}
+	return qubits
+}
+
+// Get the qubits of the register that are not in qubits, in increasing order
+func (qreg *QReg) otherQubits(qubits []int) []int {
+	in := make(map[int]bool)
+	for _, qubit := range qubits {
+		in[qubit] = true
+	}
+	others := make([]int, 0, qreg.width-len(qubits))
+	for qubit := 0; qubit < qreg.width; qubit++ {
+		if !in[qubit] {
+			others = append(others, qubit)
+		}
+	}
+	return others
+}
+
+// Get the value of the given qubits within a basis state, with qubits[i]
+// becoming bit i of the result.
+func subValue(state int, qubits []int) int {
+	value := 0
+	for i, qubit := range qubits {
+		value |= ((state >> uint(qubit)) & 1) << uint(i)
+	}
+	return value
+}
+
+// Get the basis state with the given qubits set to value, with bit i of value
+// going to qubits[i], and every other qubit 0.  This is the inverse of
+// subValue.
+func spreadValue(value int, qubits []int) int {
+	state := 0
+	for i, qubit := range qubits {
+		state |= ((value >> uint(i)) & 1) << uint(qubit)
+	}
+	return state
+}
+
+// Get the probability of observing a value on a set of qubits
+func (qreg *QReg) QubitsProb(qubits []int, value int) float64 {
+	prob := float64(0.0)
+	for state, _ := range qreg.amplitudes {
+		if subValue(state, qubits) == value {
+			prob += qreg.StateProb(state)
+		}
+	}
+	return prob
+}
+
+// Measure a set of qubits without collapsing their quantum state.  The result
+// has qubits[i] as bit i.
+func (qreg *QReg) MeasureQubitsPreserve(qubits []int) int {
+	r := rand.Float64()
+	sum := float64(0.0)
+	for i, _ := range qreg.amplitudes {
+		sum += qreg.StateProb(i)
+		if r < sum {
+			return subValue(i, qubits)
+		}
+	}
+	return subValue(len(qreg.amplitudes)-1, qubits)
+}
+
+// Measure a set of qubits (their quantum state will collapse).  The result has
+// qubits[i] as bit i.
+func (qreg *QReg) MeasureQubits(qubits []int) int {
+	value := qreg.MeasureQubitsPreserve(qubits)
+	amp_factor := complex(1.0/math.Sqrt(qreg.QubitsProb(qubits, value)), 0)
+	for state, amp := range qreg.amplitudes {
+		if subValue(state, qubits) == value {
+			qreg.amplitudes[state] = amp * amp_factor
+		} else {
+			qreg.amplitudes[state] = complex(0, 0)
+		}
+	}
+	return value
+}
+
 func (qreg *QReg) PrintState(index int) {
 	prob := qreg.StateProb(index)
 	largest := (1 << uint(qreg.width)) - 1
@@ -81,3 +81,110 @@ func TestQRegBSet_1BitEntangled(t *testing.T) {
 			qreg.amplitudes[1])
 	}
 }
+
+func TestQRegTensor(t *testing.T) {
+	a := NewQReg(2, 1)
+	HadamardRange(a, 1, 2)
+	b := NewQReg(1, 1)
+	qreg := a.Tensor(b)
+	if qreg.Width() != 3 {
+		t.Errorf("Bad width for tensor product = %d, want 3",
+			qreg.Width())
+	}
+	h := complex(1/math.Sqrt2, 0)
+	want := []complex128{0, 0, 0, 0, 0, h, 0, h}
+	for i, amp := range want {
+		if !closeEnough(qreg.amplitudes[i], amp) {
+			t.Errorf("Bad amplitude for state %d = %+f, want %+f",
+				i, qreg.amplitudes[i], amp)
+		}
+	}
+}
+
+func TestQRegMeasureQubits(t *testing.T) {
+	qreg := NewQReg(3, 0)
+	HadamardRange(qreg, 0, 2)
+	value := qreg.MeasureQubits([]int{1, 0})
+	if value < 0 || value > 3 {
+		t.Fatalf("Bad measurement %d", value)
+	}
+	// Qubit 1 is bit 0 of the value and qubit 0 is bit 1.
+	state := (value>>1)&1 | (value&1)<<1
+	if !verifyBasisState(qreg, state) {
+		t.Errorf("Register did not collapse to |%03b>", state)
+	}
+}
+
+func TestQRegReset(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		qreg := newBellQReg(2, 0, 1)
+		qreg.Reset(0)
+		if qreg.BProb(0, 0) != 1 {
+			t.Fatalf("Reset qubit has probability %f of being 0",
+				qreg.BProb(0, 0))
+		}
+		// The partner collapses to the measured value instead of
+		// being post-selected onto 0.
+		if !verifyBasisState(qreg, 0) && !verifyBasisState(qreg, 2) {
+			t.Fatal("Reset did not collapse the entangled partner")
+		}
+	}
+}
+
+func TestQRegAllocRelease(t *testing.T) {
+	qreg := NewQReg(2, 0)
+	Hadamard(qreg, 0)
+	qreg.DefineSub("all", 0, 1)
+	if first := qreg.Alloc(2); first != 2 {
+		t.Errorf("Bad first allocated qubit %d, want 2", first)
+	}
+	if qreg.Width() != 4 || len(qreg.amplitudes) != 16 {
+		t.Fatalf("Bad width after allocation %d", qreg.Width())
+	}
+	PauliX(qreg, 3)
+	qreg.Release(1)
+	if qreg.Width() != 3 {
+		t.Fatalf("Bad width after release %d", qreg.Width())
+	}
+	want := NewQReg(3, 4)
+	Hadamard(want, 0)
+	if !qreg.EqualsUpToPhase(want) {
+		t.Error("Bad state after release")
+	}
+	if qubits := qreg.Sub("all").Qubits(); len(qubits) != 1 || qubits[0] != 0 {
+		t.Errorf("Bad sub-register after release %v, want [0]", qubits)
+	}
+	qreg.Release(2)
+	want = NewQReg(2, 0)
+	Hadamard(want, 0)
+	if !qreg.EqualsUpToPhase(want) {
+		t.Error("Bad state after releasing a qubit set to 1")
+	}
+}
+
+func TestQRegRelease_SubReg(t *testing.T) {
+	qreg := NewQReg(3, 4)
+	high := qreg.DefineSub("high", 2)
+	low := qreg.DefineSub("low", 0)
+	qreg.Release(1)
+	// Handles taken before the release follow the renumbered qubits
+	if high.Qubit(0) != 1 || high.Measure() != 1 {
+		t.Errorf("Bad sub-register after release %v", high.Qubits())
+	}
+	qreg.Release(0)
+	defer func() {
+		if recover() == nil {
+			t.Error("Used a released sub-register")
+		}
+	}()
+	low.Measure()
+}
+
+func TestQRegAlloc_Negative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Allocated a negative number of qubits")
+		}
+	}()
+	NewQReg(2).Alloc(-1)
+}
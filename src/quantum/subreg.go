@@ -0,0 +1,108 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"fmt"
+)
+
+// Represents a named slice of the qubits of a quantum register.  Qubit i of
+// the sub-register is bit i of any value read from or written to it.
+type SubReg struct {
+	qreg   *QReg
+	name   string
+	qubits []int
+
+	// Set once every qubit of the sub-register has been released, after
+	// which it can no longer be used.
+	released bool
+}
+
+// Name a set of qubits of the register so that it can later be retrieved
+// with Sub.
+func (qreg *QReg) DefineSub(name string, qubits ...int) *SubReg {
+	if _, ok := qreg.subs[name]; ok {
+		panic(fmt.Sprintf("Sub-register %q is already defined", name))
+	}
+	qreg.checkQubits(qubits)
+	if qreg.subs == nil {
+		qreg.subs = make(map[string]*SubReg)
+	}
+	stored := make([]int, len(qubits))
+	copy(stored, qubits)
+	qreg.subs[name] = &SubReg{qreg: qreg, name: name, qubits: stored}
+	return qreg.Sub(name)
+}
+
+// Name the qubits from start up to, but not including, end.
+func (qreg *QReg) DefineSubRange(name string, start int, end int) *SubReg {
+	return qreg.DefineSub(name, qubitRange(start, end)...)
+}
+
+// Get a previously defined sub-register
+func (qreg *QReg) Sub(name string) *SubReg {
+	sub, ok := qreg.subs[name]
+	if !ok {
+		panic(fmt.Sprintf("No sub-register named %q", name))
+	}
+	return sub
+}
+
+// Get the qubits of a sub-register that is still part of its register
+func (sub *SubReg) live() []int {
+	if sub.released {
+		panic(fmt.Sprintf("Sub-register %q was released", sub.name))
+	}
+	return sub.qubits
+}
+
+// Accessor for the name of a SubReg
+func (sub *SubReg) Name() string {
+	return sub.name
+}
+
+// Accessor for the width (number of qubits) of a SubReg
+func (sub *SubReg) Width() int {
+	return len(sub.live())
+}
+
+// Get the index within the whole register of qubit i of the sub-register
+func (sub *SubReg) Qubit(i int) int {
+	return sub.live()[i]
+}
+
+// Get the indices within the whole register of the sub-register's qubits
+func (sub *SubReg) Qubits() []int {
+	qubits := make([]int, len(sub.live()))
+	copy(qubits, sub.live())
+	return qubits
+}
+
+// Get the probability of observing a value on the sub-register
+func (sub *SubReg) Prob(value int) float64 {
+	return sub.qreg.QubitsProb(sub.live(), value)
+}
+
+// Measure the sub-register without collapsing its quantum state
+func (sub *SubReg) MeasurePreserve() int {
+	return sub.qreg.MeasureQubitsPreserve(sub.live())
+}
+
+// Measure the sub-register (its quantum state will collapse)
+func (sub *SubReg) Measure() int {
+	return sub.qreg.MeasureQubits(sub.live())
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The goqu Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//      Unless required by applicable law or agreed to in writing, software
+//      distributed under the License is distributed on an "AS IS" BASIS,
+//      WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//      See the License for the specific language governing permissions and
+//      limitations under the License.
+//
+// Author: agent@local
+
+package quantum
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubRegMeasure(t *testing.T) {
+	qreg := NewQReg(5, 0)
+	qreg.DefineSubRange("input", 0, 2)
+	output := qreg.DefineSubRange("output", 2, 5)
+	NewClassicalGate(func(x int) int { return x ^ 22 }, 5).ApplyReg(qreg)
+	if value := output.Measure(); value != 5 {
+		t.Errorf("Bad output measurement = %d, want 5", value)
+	}
+	if value := qreg.Sub("input").Measure(); value != 2 {
+		t.Errorf("Bad input measurement = %d, want 2", value)
+	}
+}
+
+func TestSubRegApply(t *testing.T) {
+	qreg := NewQReg(3, 0)
+	sub := qreg.DefineSub("odd", 2, 0)
+	NewClassicalGate(func(x int) int { return x ^ 1 }, 2).ApplySub(sub)
+	if !verifyBasisState(qreg, 4) {
+		t.Error("Expected |100>.")
+	}
+	if prob := sub.Prob(1); !closeEnough(complex(prob, 0), 1) {
+		t.Errorf("Bad probability of sub-register value 1 = %f",
+			prob)
+	}
+}
+
+func TestSubRegTensor(t *testing.T) {
+	a := NewQReg(2, 0)
+	a.DefineSubRange("a", 0, 2)
+	b := NewQReg(3, 6)
+	b.DefineSubRange("b", 1, 3)
+	qreg := a.Tensor(b)
+	if qubits := qreg.Sub("b").Qubits(); qubits[0] != 3 || qubits[1] != 4 {
+		t.Errorf("Bad shifted sub-register qubits %v, want [3 4]",
+			qubits)
+	}
+	if value := qreg.Sub("b").Measure(); value != 3 {
+		t.Errorf("Bad measurement of shifted sub-register = %d, want 3",
+			value)
+	}
+	copied := qreg.Copy()
+	if copied.Sub("a").Width() != 2 {
+		t.Error("Sub-register was not copied")
+	}
+}
+
+func TestSubRegTensor_Clash(t *testing.T) {
+	a := NewQReg(1)
+	a.DefineSub("x", 0)
+	b := NewQReg(1)
+	b.DefineSub("x", 0)
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), `"x"`) {
+			t.Errorf("Bad panic for clashing sub-registers: %v", r)
+		}
+	}()
+	a.Tensor(b)
+}